@@ -0,0 +1,73 @@
+package fusereader
+
+import (
+	"strings"
+)
+
+// CodeValue is a FUSE code-list value, written within files as "CODE -- Description".
+type CodeValue struct {
+	Code        string // Code is the code-list code, such as FREE_FROM.
+	Description string // Description is the human-readable description of the code, if any.
+}
+
+// ParseCodeValue splits the given value into its code and description.
+//
+// Values without a " -- " separator are treated as a code without a description.
+func ParseCodeValue(s string) CodeValue {
+	code, description, _ := strings.Cut(s, codeDescriptionSeparator)
+
+	return CodeValue{Code: strings.TrimSpace(code), Description: strings.TrimSpace(description)}
+}
+
+// String returns the code value as written within FUSE files.
+func (c CodeValue) String() string {
+	if c.Description == "" {
+		return c.Code
+	}
+
+	return c.Code + codeDescriptionSeparator + c.Description
+}
+
+// MatchCode returns a function, suitable for FieldSpecification.Matches, that returns true if the code of a value is
+// one of the given codes.  Descriptions are ignored.
+func MatchCode(codes ...string) func(string) bool {
+	set := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		set[c] = true
+	}
+
+	return func(s string) bool {
+		return set[ParseCodeValue(s).Code]
+	}
+}
+
+// CodeLists maps header names to the codes known to be valid for values under them.
+type CodeLists map[string][]string
+
+// known returns true if the given code is valid for the given header.  Headers without a code list accept any code.
+//
+// Headers are compared after applying the given normalizer.
+func (l CodeLists) known(names headerNormalizer, header, code string) bool {
+	header = names.normalize(header)
+
+	for h, codes := range l {
+		if names.normalize(h) != header {
+			continue
+		}
+
+		for _, c := range codes {
+			if c == code {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// Known returns true if the given code is valid for the given header.  Headers without a code list accept any code.
+func (l CodeLists) Known(header, code string) bool {
+	return l.known(headerNormalizer{}, header, code)
+}
@@ -0,0 +1,61 @@
+package fusereader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeValue(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want CodeValue
+	}{
+		{name: "Code and description", s: "FREE_FROM -- Free from", want: CodeValue{Code: "FREE_FROM", Description: "Free from"}},
+		{name: "Code only", s: "FREE_FROM", want: CodeValue{Code: "FREE_FROM"}},
+		{name: "Padded", s: " MILK  --  Milk ", want: CodeValue{Code: "MILK", Description: "Milk"}},
+		{name: "Empty", s: "", want: CodeValue{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseCodeValue(tt.s))
+		})
+	}
+
+	assert.Equal(t, "FREE_FROM -- Free from", ParseCodeValue("FREE_FROM -- Free from").String())
+}
+
+func TestMatchCode(t *testing.T) {
+	matches := MatchCode("FREE_FROM", "CONTAINS")
+
+	assert.True(t, matches("FREE_FROM -- Free from"))
+	assert.True(t, matches("CONTAINS"))
+	assert.False(t, matches("MAY_CONTAIN -- May contain"))
+	assert.False(t, matches("Free from"))
+}
+
+func TestGetFieldsForValidateCodes(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	retrieve := validRetrieveSpec()
+	retrieve.Field.Matches = func(s string) bool { return s != "" }
+	retrieve.Header.FanOut = true
+
+	var got []Warning
+	lists := CodeLists{"level of containment": {"CONTAINS", "MAY_CONTAIN"}}
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, c, NormalizeHeaders(HeaderNormalization{FoldCase: true}), ValidateCodes(lists), ReportWarnings(func(w Warning) { got = append(got, w) }))
+	assert.Nil(t, err)
+	close(c)
+
+	assert.Len(t, collectFields(c), 3)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, WarningUnknownCode, got[0].Kind)
+		assert.Equal(t, "J2", got[0].Cell)
+	}
+
+	assert.True(t, lists.Known("Allergen Type Code", "ANYTHING"))
+	assert.False(t, lists.Known("level of containment", "FREE_FROM"))
+}
@@ -0,0 +1,175 @@
+package fusereader
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DuplicateReport describes items appearing more than once across, or within, a set of files.
+type DuplicateReport struct {
+	Groups []DuplicateGroup `json:"groups"` // Groups contains a group per duplicated item, ordered by Item ID and then provider GLN.
+}
+
+// DuplicateGroup describes every occurrence of a duplicated item.
+type DuplicateGroup struct {
+	ItemID      string           `json:"itemID"`                // ItemID is the item's Item ID, as written on its first occurrence.
+	ProviderGLN string           `json:"providerGLN,omitempty"` // ProviderGLN is the item's Information Provider GLN, if duplicates are keyed by provider.
+	Identical   bool             `json:"identical"`             // Identical is true if every occurrence has the same content hash, or false if the occurrences conflict.
+	Occurrences []ItemOccurrence `json:"occurrences"`           // Occurrences contains each occurrence of the item, in file and then row order.
+}
+
+// ItemOccurrence describes a single occurrence of an item.
+type ItemOccurrence struct {
+	File string `json:"file"` // File is the path of the file containing the occurrence.
+	Row  int    `json:"row"`  // Row is the one-based row number of the occurrence's first row.
+	Hash string `json:"hash"` // Hash is the hex-encoded SHA-256 hash of the occurrence's cell contents.
+}
+
+// FindDuplicates reports items whose Item ID appears more than once across, or within, the given files, with GTINs
+// compared regardless of form.  With KeyDuplicatesByProvider, items are only duplicates if their Information Provider
+// GLNs also match.
+//
+// Each file is read in a single pass.
+func FindDuplicates(files []string, opts ...Option) (DuplicateReport, error) {
+	_, byProvider := keyDuplicatesByProviderFrom(opts...)
+
+	type key struct {
+		item, provider string
+	}
+
+	groups := make(map[key]*DuplicateGroup)
+
+	err := readItems(files, func(it Item) error {
+		k := key{item: itemKey(it.ID)}
+		if byProvider {
+			k.provider = normalizeGLN(it.Value(headerInformationProviderGLN))
+		}
+
+		g, exist := groups[k]
+		if !exist {
+			g = &DuplicateGroup{ItemID: it.ID}
+			if byProvider {
+				g.ProviderGLN = strings.TrimSpace(it.Value(headerInformationProviderGLN))
+			}
+
+			groups[k] = g
+		}
+
+		g.Occurrences = append(g.Occurrences, ItemOccurrence{File: it.File, Row: it.BeginningRow, Hash: it.ContentHash()})
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return DuplicateReport{}, fmt.Errorf("error while reading items: %w", err)
+	}
+
+	report := DuplicateReport{Groups: []DuplicateGroup{}}
+
+	for _, g := range groups {
+		if len(g.Occurrences) < 2 {
+			continue
+		}
+
+		g.Identical = true
+		for _, o := range g.Occurrences[1:] {
+			if o.Hash != g.Occurrences[0].Hash {
+				g.Identical = false
+				break
+			}
+		}
+
+		report.Groups = append(report.Groups, *g)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].ItemID != report.Groups[j].ItemID {
+			return report.Groups[i].ItemID < report.Groups[j].ItemID
+		}
+
+		return report.Groups[i].ProviderGLN < report.Groups[j].ProviderGLN
+	})
+
+	return report, nil
+}
+
+// ContentHash returns the hex-encoded SHA-256 hash of the item's cell contents.
+//
+// The hash covers every cell of every row of the item in column order, ignoring trailing empty cells and rows, such
+// that items with byte-identical contents have equal hashes regardless of the file or row they occupy.
+func (it Item) ContentHash() string {
+	h := sha256.New()
+
+	rows := it.Rows
+	for len(rows) > 0 && isBlankRow(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+
+	var size [8]byte
+
+	for _, row := range rows {
+		cells := row
+		for len(cells) > 0 && cells[len(cells)-1] == "" {
+			cells = cells[:len(cells)-1]
+		}
+
+		binary.BigEndian.PutUint64(size[:], uint64(len(cells)))
+		h.Write(size[:])
+
+		for _, c := range cells {
+			binary.BigEndian.PutUint64(size[:], uint64(len(c)))
+			h.Write(size[:])
+			h.Write([]byte(c))
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Conflicting returns the groups whose occurrences do not all share the same contents.
+func (r DuplicateReport) Conflicting() []DuplicateGroup {
+	var out []DuplicateGroup
+
+	for _, g := range r.Groups {
+		if !g.Identical {
+			out = append(out, g)
+		}
+	}
+
+	return out
+}
+
+// JSON returns the report encoded as indented JSON.
+func (r DuplicateReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns a human-readable description of the report, with a line per occurrence.
+func (r DuplicateReport) String() string {
+	var b strings.Builder
+
+	for _, g := range r.Groups {
+		status := "conflicting"
+		if g.Identical {
+			status = "identical"
+		}
+
+		name := g.ItemID
+		if g.ProviderGLN != "" {
+			name += " from " + g.ProviderGLN
+		}
+
+		fmt.Fprintf(&b, "%s: %d occurrences, %s\n", name, len(g.Occurrences), status)
+
+		for _, o := range g.Occurrences {
+			fmt.Fprintf(&b, "  %s row %d (%s)\n", filepath.Base(o.File), o.Row, o.Hash[:12])
+		}
+	}
+
+	return b.String()
+}
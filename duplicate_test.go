@@ -0,0 +1,63 @@
+package fusereader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	rows := itemTestRows()
+	first := newTestFile(t, rows)
+
+	rows = append(rows, []string{itemRecordType, "ADD", "Y", "0614141000029", "Globex", "GTIN", "077661003169", "", "SOYBEANS -- Soybeans", "CONTAINS -- Contains"})
+	second := newTestFile(t, append([][]string{rows[0]}, rows[3:]...))
+
+	got, err := FindDuplicates([]string{first, second})
+	require.Nil(t, err)
+
+	if assert.Len(t, got.Groups, 2) {
+		assert.Equal(t, "00077661003169", got.Groups[0].ItemID)
+		assert.False(t, got.Groups[0].Identical)
+		assert.Equal(t, []ItemOccurrence{
+			{File: first, Row: 5, Hash: got.Groups[0].Occurrences[0].Hash},
+			{File: second, Row: 3, Hash: got.Groups[0].Occurrences[1].Hash},
+			{File: second, Row: 4, Hash: got.Groups[0].Occurrences[2].Hash},
+		}, got.Groups[0].Occurrences)
+		assert.Equal(t, got.Groups[0].Occurrences[0].Hash, got.Groups[0].Occurrences[1].Hash)
+
+		assert.Equal(t, "10011110603088", got.Groups[1].ItemID)
+		assert.True(t, got.Groups[1].Identical)
+	}
+
+	assert.Len(t, got.Conflicting(), 1)
+
+	got, err = FindDuplicates([]string{first, second}, KeyDuplicatesByProvider())
+	require.Nil(t, err)
+
+	if assert.Len(t, got.Groups, 2) {
+		assert.Equal(t, "0614141000012", got.Groups[0].ProviderGLN)
+		assert.True(t, got.Groups[0].Identical)
+		assert.Len(t, got.Groups[0].Occurrences, 2)
+	}
+	assert.Contains(t, got.String(), "00077661003169 from 0614141000012: 2 occurrences, identical")
+
+	got, err = FindDuplicates([]string{first}, KeyDuplicatesByProvider())
+	require.Nil(t, err)
+
+	b, err := got.JSON()
+	require.Nil(t, err)
+	assert.Contains(t, string(b), `"groups": []`)
+}
+
+func TestItemContentHash(t *testing.T) {
+	a := Item{Rows: [][]string{{"ITEM", "ADD", ""}, {""}}}
+	b := Item{Rows: [][]string{{"ITEM", "ADD"}}, BeginningRow: 10}
+	c := Item{Rows: [][]string{{"ITEM", "", "ADD"}}}
+	d := Item{Rows: [][]string{{"ITEMADD"}}}
+
+	assert.Equal(t, a.ContentHash(), b.ContentHash())
+	assert.NotEqual(t, a.ContentHash(), c.ContentHash())
+	assert.NotEqual(t, a.ContentHash(), d.ContentHash())
+}
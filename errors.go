@@ -0,0 +1,68 @@
+package fusereader
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrFileOpen          = errors.New("file could not be opened")    // ErrFileOpen indicates that a file could not be opened.
+	ErrHeaderRowNotFound = errors.New("header row not found")        // ErrHeaderRowNotFound indicates that a file's header row could not be identified.
+	ErrHeaderNotFound    = errors.New("header not found")            // ErrHeaderNotFound indicates that a header specification could not be resolved.
+	ErrOffsetOutOfRange  = errors.New("offset out of range")         // ErrOffsetOutOfRange indicates that a column, or a column offset from a header, lies outside of the headers.
+	ErrTimeout           = errors.New("timed out waiting on buffer") // ErrTimeout indicates that a worker timed out while sending to or receiving from a buffer.
+	ErrValueParse        = errors.New("value could not be parsed")   // ErrValueParse indicates that a field's contents could not be parsed as the requested type.
+	ErrUnitConversion    = errors.New("unit could not be converted") // ErrUnitConversion indicates that a measurement's unit is unknown or incompatible with the requested unit.
+)
+
+// Error describes a failure along with the context in which it occurred.
+//
+// Error supports errors.Is for its Kind, as well as errors.Is and errors.As for the underlying error.
+type Error struct {
+	Kind   error  // Kind is the sentinel describing the category of failure, such as ErrHeaderNotFound.
+	File   string // File is the path of the file being processed, if any.
+	SpecID string // SpecID is the ID of the field location or retrieval being processed, if any.
+	Header string // Header describes the header being resolved, if any.
+	Cell   string // Cell is the address of the cell being processed in A1 format, if any.
+	Err    error  // Err is the underlying error, if any.
+}
+
+// Error returns a description of the failure and its context.
+func (e *Error) Error() string {
+	var context []string
+
+	if e.File != "" {
+		context = append(context, fmt.Sprintf("file %s", filepath.Base(e.File)))
+	}
+	if e.SpecID != "" {
+		context = append(context, fmt.Sprintf("spec %q", e.SpecID))
+	}
+	if e.Header != "" {
+		context = append(context, fmt.Sprintf("header %q", e.Header))
+	}
+	if e.Cell != "" {
+		context = append(context, fmt.Sprintf("cell %s", e.Cell))
+	}
+
+	msg := fmt.Sprint(e.Kind)
+	if len(context) > 0 {
+		msg += " (" + strings.Join(context, ", ") + ")"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if the target is the error's Kind.
+func (e *Error) Is(target error) bool {
+	return e.Kind != nil && target == e.Kind
+}
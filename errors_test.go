@@ -0,0 +1,49 @@
+package fusereader
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorKinds(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+	noHeader := newTestFile(t, [][]string{{"Banner"}})
+
+	tests := []struct {
+		name       string
+		files      []string
+		locate     FieldLocation
+		retrieve   FieldRetrieval
+		wantKind   error
+		wantSpecID string
+	}{
+		{name: "File open", files: []string{"bad_file.xlsx"}, locate: validFieldLocation(), retrieve: validRetrieveSpec(), wantKind: ErrFileOpen},
+		{name: "Header row", files: []string{noHeader}, locate: validFieldLocation(), retrieve: validRetrieveSpec(), wantKind: ErrHeaderRowNotFound},
+		{name: "Header", files: []string{path}, locate: validFindSpecKeyHeaderOverride("Foo header"), retrieve: validRetrieveSpec(), wantKind: ErrHeaderNotFound, wantSpecID: "Location spec 01"},
+		{name: "Offset", files: []string{path}, locate: validFieldLocation(), retrieve: validRetrieveSpecOverrideOffsets([]int{20000}), wantKind: ErrOffsetOutOfRange, wantSpecID: "Retrieve spec 01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GetFields(tt.files, []FieldLocation{tt.locate}, []FieldRetrieval{tt.retrieve}, make(chan field, 10))
+			assert.True(t, errors.Is(err, tt.wantKind), "got %v", err)
+
+			var e *Error
+			if assert.True(t, errors.As(err, &e)) {
+				assert.Equal(t, tt.files[0], e.File)
+				assert.Equal(t, tt.wantSpecID, e.SpecID)
+			}
+		})
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	err := cacheFile("bad_file.xlsx")
+
+	assert.True(t, errors.Is(err, ErrFileOpen))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+	assert.False(t, errors.Is(err, ErrTimeout))
+	assert.Contains(t, err.Error(), "file bad_file.xlsx")
+}
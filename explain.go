@@ -0,0 +1,295 @@
+package fusereader
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Explanation describes how the specifications given to Explain resolved within each file, and how they matched.
+type Explanation struct {
+	Files []FileExplanation `json:"files"` // Files contains the explanation for each file.
+}
+
+// FileExplanation describes how specifications resolved and matched within a single file.
+type FileExplanation struct {
+	File          string            `json:"file"`           // File is the path of the file.
+	Err           error             `json:"-"`              // Err is the reason the file could not be scanned, if any.
+	ItemsScanned  int               `json:"itemsScanned"`   // ItemsScanned is the number of items read from the file.
+	ItemsFiltered int               `json:"itemsFiltered"`  // ItemsFiltered is the number of scanned items excluded by item filters, which are not matched against any specification.
+	Locate        []SpecExplanation `json:"locate"`         // Locate contains an explanation for each field location.
+	Retrieve      []SpecExplanation `json:"retrieve"`       // Retrieve contains an explanation for each field retrieval.
+	Item          *ItemExplanation  `json:"item,omitempty"` // Item explains the item given to ExplainItem, if it was found in the file.
+}
+
+// SpecExplanation describes how a single specification resolved and matched within a file.
+type SpecExplanation struct {
+	SpecID       string   `json:"specID"`       // SpecID is the ID of the specification.
+	Err          error    `json:"-"`            // Err is the reason the specification's header could not be resolved, if any.
+	Headers      []string `json:"headers"`      // Headers contains the text of each resolved key header.
+	Columns      []string `json:"columns"`      // Columns contains the letter of each resolved key header's column.
+	GroupRoots   []int    `json:"groupRoots"`   // GroupRoots contains the zero-based root index of each resolved key header's group, with -1 denoting the first group.
+	MatchCount   int      `json:"matchCount"`   // MatchCount is the number of cells for which Matches returned true.
+	MatchedItems int      `json:"matchedItems"` // MatchedItems is the number of items containing a match once Matches had returned true at least OnMatch times within the file.
+}
+
+// ItemExplanation describes how the field locations treated a single item.
+type ItemExplanation struct {
+	ItemID       string   `json:"itemID"`               // ItemID is the ID of the item.
+	BeginningRow int      `json:"beginningRow"`         // BeginningRow is the one-based number of the item's first row.
+	Matched      bool     `json:"matched"`              // Matched is true if the item was not filtered and every field location accepted it.
+	FilteredBy   string   `json:"filteredBy,omitempty"` // FilteredBy is the reason an item filter, such as FilterProviders, excluded the item, if one did.
+	RejectedBy   []string `json:"rejectedBy"`           // RejectedBy contains the IDs of the field locations that did not accept the item.
+}
+
+// Explain performs a dry run of GetFields, reporting how each specification resolved within each file and how often
+// it matched, without retrieving any fields.
+//
+// Items excluded by item filters, such as FilterProviders, are counted but not matched against any specification.
+// With ExplainItem, the explanation for each file also describes which item filter or field locations rejected the
+// given item.
+//
+// As with GetFields, calls are serialised with other runs.
+func Explain(files []string, locate []FieldLocation, retrieve []FieldRetrieval, opts ...Option) (out Explanation, err error) {
+	if len(files) == 0 {
+		return Explanation{}, fmt.Errorf("no files were given")
+	}
+	runMu.Lock()
+	defer runMu.Unlock()
+	defer func() {
+		removeHeaderCaches()
+
+		cErr := closeFiles()
+		if err == nil && cErr != nil {
+			err = fmt.Errorf("error while closing files: %w", cErr)
+		}
+	}()
+
+	report := &RunReport{}
+
+	if _, err := buildCachesTolerantly(files, report, opts...); err != nil {
+		return Explanation{}, fmt.Errorf("error while building caches: %w", err)
+	}
+
+	for _, file := range files {
+		fe := FileExplanation{File: file, Err: report.Errors[file]}
+
+		if fe.Err == nil {
+			if err := explainFile(&fe, locate, retrieve, opts...); err != nil {
+				fe.Err = err
+			}
+		}
+
+		out.Files = append(out.Files, fe)
+	}
+
+	return out, nil
+}
+
+// explainedSpec pairs a specification's header and field specifications with its explanation and resolved indices.
+type explainedSpec struct {
+	header      HeaderSpecification
+	field       FieldSpecification
+	explanation *SpecExplanation
+	indices     []int
+	matchCount  int  // matchCount is the running number of matches within the file, as counted by GetFields.
+	itemMatched bool // itemMatched is true if the most recently scanned item was matched.
+}
+
+// explainFile populates the given file explanation by scanning the items of its file.
+func explainFile(fe *FileExplanation, locate []FieldLocation, retrieve []FieldRetrieval, opts ...Option) error {
+	fe.Locate = make([]SpecExplanation, len(locate))
+	fe.Retrieve = make([]SpecExplanation, len(retrieve))
+
+	var specs, locateSpecs []*explainedSpec
+
+	for i, l := range locate {
+		fe.Locate[i].SpecID = l.ID
+		s := &explainedSpec{header: l.Header, field: l.Field, explanation: &fe.Locate[i]}
+		specs, locateSpecs = append(specs, s), append(locateSpecs, s)
+	}
+
+	for i, r := range retrieve {
+		fe.Retrieve[i].SpecID = r.ID
+		specs = append(specs, &explainedSpec{header: r.Header, field: r.Field, explanation: &fe.Retrieve[i]})
+	}
+
+	for _, s := range specs {
+		if err := resolveExplainedSpec(fe.File, s); err != nil {
+			s.explanation.Err = &Error{Kind: ErrHeaderNotFound, File: fe.File, SpecID: s.explanation.SpecID, Header: s.header.describe(), Err: err}
+		}
+	}
+
+	recordTypeIndex, err := headerIndex(fe.File, headerRecordType, []string{headerOperation}, 1)
+	if err != nil {
+		return &Error{Kind: ErrHeaderNotFound, File: fe.File, Header: headerRecordType, Err: err}
+	}
+
+	itemIDIndex, err := headerIndex(fe.File, headerItemID, []string{headerOperation}, 1)
+	if err != nil {
+		return &Error{Kind: ErrHeaderNotFound, File: fe.File, Header: headerItemID, Err: err}
+	}
+
+	filter, err := newItemFilter(fe.File, opts...)
+	if err != nil {
+		return err
+	}
+
+	fi, err := getFile(fe.File)
+	if err != nil {
+		return fmt.Errorf("error while getting file pointer for %s: %w", filepath.Base(fe.File), err)
+	}
+
+	rows, err := fi.Rows(worksheetFSItem)
+	if err != nil {
+		return fmt.Errorf("error while getting row iterator for %s: %w", filepath.Base(fe.File), err)
+	}
+	defer rows.Close()
+
+	traced, tracing := explainItemFrom(opts...)
+	segmenter := newItemSegmenter(itemBoundariesFrom(opts...), recordTypeIndex)
+
+	scan := func(item *itemSegment) {
+		if item == nil {
+			return
+		}
+
+		fe.ItemsScanned++
+
+		traceItem := tracing && cellAt(item.rows[0], itemIDIndex) == traced.itemID && fe.Item == nil
+
+		if reason := filter.rejection(item.rows[0]); reason != "" {
+			fe.ItemsFiltered++
+
+			if traceItem {
+				fe.Item = &ItemExplanation{ItemID: traced.itemID, BeginningRow: item.beginningRow, FilteredBy: reason, RejectedBy: []string{}}
+			}
+
+			return
+		}
+
+		for _, s := range specs {
+			s.scan(item)
+		}
+
+		if traceItem {
+			fe.Item = &ItemExplanation{ItemID: traced.itemID, BeginningRow: item.beginningRow, RejectedBy: []string{}}
+
+			for _, s := range locateSpecs {
+				if !s.itemMatched {
+					fe.Item.RejectedBy = append(fe.Item.RejectedBy, s.explanation.SpecID)
+				}
+			}
+
+			fe.Item.Matched = len(fe.Item.RejectedBy) == 0
+		}
+	}
+
+	currentRow := 0
+	for rows.Next() {
+		currentRow++
+
+		cells, err := rows.Columns()
+		if err != nil {
+			return fmt.Errorf("error while reading row %d in %s: %w", currentRow, filepath.Base(fe.File), err)
+		}
+
+		if currentRow <= headerRowCache[fe.File] {
+			continue
+		}
+
+		completed, stop := segmenter.push(currentRow, cells, nil)
+		scan(completed)
+
+		if stop {
+			break
+		}
+	}
+
+	scan(segmenter.flush())
+
+	return nil
+}
+
+// resolveExplainedSpec records the resolved key header indices of the given spec within the given file.
+func resolveExplainedSpec(file string, s *explainedSpec) error {
+	indices, err := headerIndicesFor(file, s.header)
+	if err != nil {
+		return err
+	}
+
+	s.indices = indices
+
+	for _, index := range indices {
+		col, err := excelize.ColumnNumberToName(index + 1)
+		if err != nil {
+			return fmt.Errorf("error while converting column %d to a name: %w", index+1, err)
+		}
+
+		s.explanation.Headers = append(s.explanation.Headers, headerTextAt(file, index))
+		s.explanation.Columns = append(s.explanation.Columns, col)
+		s.explanation.GroupRoots = append(s.explanation.GroupRoots, groupRootOf(file, index))
+	}
+
+	return nil
+}
+
+// scan evaluates the spec's Matches function against the resolved cells of the given item.
+//
+// As with GetFields, matches are counted across the file's items, so an item is matched if it contains a match once
+// OnMatch matches have been seen in the file.
+func (s *explainedSpec) scan(item *itemSegment) {
+	s.itemMatched = false
+
+	if s.field.Matches == nil {
+		return
+	}
+
+	itemMatches := 0
+
+	for _, row := range item.rows {
+		for _, index := range s.indices {
+			if index < len(row) && s.field.Matches(row[index]) {
+				itemMatches++
+			}
+		}
+	}
+
+	s.matchCount += itemMatches
+	s.explanation.MatchCount += itemMatches
+
+	if itemMatches > 0 && s.matchCount >= onMatchThreshold(s.field.OnMatch) {
+		s.itemMatched = true
+		s.explanation.MatchedItems++
+	}
+}
+
+// onMatchThreshold returns the number of matches described by the given OnMatch value.
+func onMatchThreshold(onMatch int) int {
+	if onMatch <= 1 {
+		return 1
+	}
+
+	return onMatch
+}
+
+// groupRootOf returns the zero-based root index of the group containing the header at the given index of the given
+// file, with -1 denoting the first group.
+func groupRootOf(file string, index int) int {
+	if _, exist := headerCache[sharedHeaderCacheKey]; exist {
+		file = sharedHeaderCacheKey
+	}
+
+	tree, exist := headerGroupRootCache[file]
+	if !exist {
+		return -1
+	}
+
+	node, found := tree.Floor(index)
+	if !found {
+		return -1
+	}
+
+	return node.Key.(int)
+}
@@ -0,0 +1,122 @@
+package fusereader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	byID := validFieldLocation()
+	byID.Field.Matches = func(s string) bool { return s == "10011110603088" }
+
+	bySoy := FieldLocation{
+		ID:     "Soy",
+		Header: HeaderSpecification{Key: "Allergen Type Code", OthersInGroup: []string{"Level Of Containment"}, FanOut: true},
+		Field:  FieldSpecification{Matches: func(s string) bool { return strings.HasPrefix(s, "SOYBEANS") }},
+	}
+
+	got, err := Explain([]string{path, "bad_file.xlsx"}, []FieldLocation{byID, bySoy}, []FieldRetrieval{validRetrieveSpec(), validRetrieveSpecOverrideKey("Foo header")}, ExplainItem("10011110603088"))
+	require.Nil(t, err)
+	require.Len(t, got.Files, 2)
+
+	fe := got.Files[0]
+	assert.Nil(t, fe.Err)
+	assert.Equal(t, 3, fe.ItemsScanned)
+
+	assert.Equal(t, []string{"G"}, fe.Locate[0].Columns)
+	assert.Equal(t, 1, fe.Locate[0].MatchCount)
+	assert.Equal(t, []string{"I", "L"}, fe.Locate[1].Columns)
+	assert.Equal(t, []int{7, 10}, fe.Locate[1].GroupRoots)
+	assert.Equal(t, 2, fe.Locate[1].MatchCount)
+	assert.Equal(t, 2, fe.Locate[1].MatchedItems)
+
+	assert.Equal(t, []string{"Allergen Type Code"}, fe.Retrieve[0].Headers)
+	assert.Nil(t, fe.Retrieve[0].Err)
+	assert.ErrorIs(t, fe.Retrieve[1].Err, ErrHeaderNotFound)
+
+	if assert.NotNil(t, fe.Item) {
+		assert.Equal(t, 4, fe.Item.BeginningRow)
+		assert.False(t, fe.Item.Matched)
+		assert.Equal(t, []string{"Soy"}, fe.Item.RejectedBy)
+	}
+
+	assert.ErrorIs(t, got.Files[1].Err, ErrFileOpen)
+}
+
+func TestExplainForOnMatch(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	bySoy := FieldLocation{
+		ID:     "Soy",
+		Header: HeaderSpecification{Key: "Allergen Type Code", OthersInGroup: []string{"Level Of Containment"}, FanOut: true},
+		Field:  FieldSpecification{Matches: func(s string) bool { return strings.HasPrefix(s, "SOYBEANS") }, OnMatch: 2},
+	}
+
+	got, err := Explain([]string{path}, []FieldLocation{bySoy}, nil, ExplainItem("00077661003169"))
+	require.Nil(t, err)
+	require.Len(t, got.Files, 1)
+
+	fe := got.Files[0]
+	assert.Equal(t, 2, fe.Locate[0].MatchCount)
+	assert.Equal(t, 1, fe.Locate[0].MatchedItems)
+
+	if assert.NotNil(t, fe.Item) {
+		assert.True(t, fe.Item.Matched)
+		assert.Empty(t, fe.Item.RejectedBy)
+	}
+}
+
+func TestExplainForItemFilters(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	byID := validFieldLocation()
+	byID.Field.Matches = func(s string) bool { return s == "10011110603088" }
+
+	tests := []struct {
+		name         string
+		filter       Option
+		wantFiltered int
+		wantReason   string
+	}{
+		{name: "Providers", filter: FilterProviders("0614141000012"), wantFiltered: 1, wantReason: `provider "0614141000029" is not accepted`},
+		{name: "Import flagged", filter: ImportFlaggedOnly(), wantFiltered: 1, wantReason: "item is not flagged for import"},
+		{name: "Operations", filter: FilterOperations(OperationAdd), wantFiltered: 1, wantReason: `operation "CHANGE" is not accepted`},
+		{name: "Admitted", filter: FilterOperations(OperationChange), wantFiltered: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Explain([]string{path}, []FieldLocation{byID}, nil, tt.filter, ExplainItem("10011110603088"))
+			require.Nil(t, err)
+			require.Len(t, got.Files, 1)
+
+			fe := got.Files[0]
+			assert.Nil(t, fe.Err)
+			assert.Equal(t, 3, fe.ItemsScanned)
+			assert.Equal(t, tt.wantFiltered, fe.ItemsFiltered)
+
+			if assert.NotNil(t, fe.Item) {
+				assert.Equal(t, tt.wantReason, fe.Item.FilteredBy)
+				assert.Equal(t, tt.wantReason == "", fe.Item.Matched)
+
+				if tt.wantReason == "" {
+					assert.Equal(t, 1, fe.Locate[0].MatchedItems)
+				} else {
+					assert.Equal(t, 0, fe.Locate[0].MatchCount)
+				}
+			}
+		})
+	}
+}
+
+func validRetrieveSpecOverrideKey(h string) FieldRetrieval {
+	r := validRetrieveSpec()
+	r.ID = "Retrieve spec 02"
+	r.Header.Key = h
+
+	return r
+}
@@ -1,29 +1,69 @@
 package fusereader
 
+import (
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
 // Field represents a field within a FUSE file.
 type Field interface {
-	SetSpecID(string)  // SetSpecID sets the ID of the field specification responsible for the retrieval of this field.
-	SpecID() string    // SpecID returns the ID of the field specification responsible for the retrieval of this field.
-	SetItemID(string)  // SetItemID sets the item ID associated with the field.
-	ItemID() string    // ItemID returns the item ID associated with the field.
-	SetHeader(string)  // SetHeader sets the column header for the field.
-	Header() string    // Header returns the column header for the field.
-	SetValue(string)   // SetValue sets the contents of the field.
-	Value() string     // Value returns the contents of the field.
-	SetFile(string)    // SetFile sets the filename of the spreadsheet the field was retrieved from.
-	File() string      // File returns the filename of the spreadsheet the field was retrieved from.
-	SetAddress(string) // SetAddress sets the address of the cell in A1 format.
-	Address() string   // Address returns the address of the cell in A1 format.
+	SetSpecID(string)                  // SetSpecID sets the ID of the field specification responsible for the retrieval of this field.
+	SpecID() string                    // SpecID returns the ID of the field specification responsible for the retrieval of this field.
+	SetItemID(string)                  // SetItemID sets the item ID associated with the field.
+	ItemID() string                    // ItemID returns the item ID associated with the field.
+	SetHeader(string)                  // SetHeader sets the column header for the field.
+	Header() string                    // Header returns the column header for the field.
+	SetValue(string)                   // SetValue sets the contents of the field.
+	Value() string                     // Value returns the contents of the field.
+	SetFile(string)                    // SetFile sets the filename of the spreadsheet the field was retrieved from.
+	File() string                      // File returns the filename of the spreadsheet the field was retrieved from.
+	SetAddress(string)                 // SetAddress sets the address of the cell in A1 format.
+	Address() string                   // Address returns the address of the cell in A1 format.
+	SetProvenance(Provenance)          // SetProvenance sets the details of where and how the field was retrieved.
+	Provenance() Provenance            // Provenance returns the details of where and how the field was retrieved.
+	SetRawValue(string)                // SetRawValue sets the contents of the field without the cell's number format applied.
+	RawValue() string                  // RawValue returns the contents of the field without the cell's number format applied.
+	SetCellType(excelize.CellType)     // SetCellType sets the cell's data type, or CellTypeUnset if it is not known.
+	CellType() excelize.CellType       // CellType returns the cell's data type, or CellTypeUnset if it could not be determined.
+	Int() (int, error)                 // Int returns the contents of the field as an integer.
+	Decimal() (float64, error)         // Decimal returns the contents of the field as a decimal number.
+	Bool() (bool, error)               // Bool returns the contents of the field as a boolean, accepting TRUE/FALSE and Y/N.
+	Time() (time.Time, error)          // Time returns the contents of the field as a time, accepting ISO 8601 strings and, for numeric cells, Excel serial dates.
+	Code() (string, error)             // Code returns the code within the contents of the field, omitting any description.
+	Description() string               // Description returns the description within the contents of the field, omitting any code.
+	SetUnit(string)                    // SetUnit sets the unit of measure accompanying the field, as retrieved from the unit header of its group.
+	Unit() string                      // Unit returns the unit of measure accompanying the field, as retrieved from the unit header of its group.
+	Measurement() (Measurement, error) // Measurement returns the contents of the field as a quantity in its unit of measure.
+	SetOperation(Operation)            // SetOperation sets the operation of the item associated with the field.
+	Operation() Operation              // Operation returns the operation of the item associated with the field.
+}
+
+// Provenance describes where and how a field was retrieved, for use in audit trails.
+type Provenance struct {
+	Sheet           string   // Sheet is the name of the worksheet the field was retrieved from.
+	Row             int      // Row is the zero-based index of the field's row.
+	Column          int      // Column is the zero-based index of the field's column.
+	GroupRoot       int      // GroupRoot is the zero-based root index of the key header's group, with -1 denoting the first group.
+	GroupOccurrence int      // GroupOccurrence is the one-based occurrence of the key header among headers of the same name.
+	LocateSpecIDs   []string // LocateSpecIDs contains the IDs of the field locations that selected the field's item.
+	MatchOccurrence int      // MatchOccurrence is the number of times the retrieval's Matches function had returned true when the field was retrieved.
+	Offset          int      // Offset is the offset from the key header that produced the field.
 }
 
 // field represents a field within a FUSE file.
 type field struct {
-	specID  string // specID is the ID of the field specification responsible for the retrieval of this field.
-	itemID  string // itemID is the item ID associated with the field.
-	header  string // header is the column header for the field.
-	value   string // value is the contents of the field.
-	file    string // file is the filename of the spreadsheet the field was retrieved from.
-	address string // address is the address of the cell in A1 format.
+	specID     string            // specID is the ID of the field specification responsible for the retrieval of this field.
+	itemID     string            // itemID is the item ID associated with the field.
+	header     string            // header is the column header for the field.
+	value      string            // value is the contents of the field.
+	file       string            // file is the filename of the spreadsheet the field was retrieved from.
+	address    string            // address is the address of the cell in A1 format.
+	provenance Provenance        // provenance contains the details of where and how the field was retrieved.
+	rawValue   string            // rawValue is the contents of the field without the cell's number format applied.
+	cellType   excelize.CellType // cellType is the cell's data type, or CellTypeUnset if it is not known.
+	unit       string            // unit is the unit of measure accompanying the field.
+	operation  Operation         // operation is the operation of the item associated with the field.
 }
 
 // SetSpecID sets the ID of the field specification responsible for the retrieval of this field.
@@ -85,3 +125,56 @@ func (f *field) SetAddress(s string) {
 func (f field) Address() string {
 	return f.address
 }
+
+// SetProvenance sets the details of where and how the field was retrieved.
+func (f *field) SetProvenance(p Provenance) {
+	f.provenance = p
+}
+
+// Provenance returns the details of where and how the field was retrieved.
+func (f field) Provenance() Provenance {
+	return f.provenance
+}
+
+// SetRawValue sets the contents of the field without the cell's number format applied.
+func (f *field) SetRawValue(s string) {
+	f.rawValue = s
+}
+
+// RawValue returns the contents of the field without the cell's number format applied.
+func (f field) RawValue() string {
+	return f.rawValue
+}
+
+// SetCellType sets the cell's data type, or CellTypeUnset if it is not known.
+func (f *field) SetCellType(t excelize.CellType) {
+	f.cellType = t
+}
+
+// CellType returns the cell's data type, or CellTypeUnset if it could not be determined.
+//
+// As the type is determined from the cell's contents, numbers without a number format and numeric text are both
+// CellTypeUnset.
+func (f field) CellType() excelize.CellType {
+	return f.cellType
+}
+
+// SetUnit sets the unit of measure accompanying the field, as retrieved from the unit header of its group.
+func (f *field) SetUnit(s string) {
+	f.unit = s
+}
+
+// Unit returns the unit of measure accompanying the field, as retrieved from the unit header of its group.
+func (f field) Unit() string {
+	return f.unit
+}
+
+// SetOperation sets the operation of the item associated with the field.
+func (f *field) SetOperation(op Operation) {
+	f.operation = op
+}
+
+// Operation returns the operation of the item associated with the field.
+func (f field) Operation() Operation {
+	return f.operation
+}
@@ -74,7 +74,7 @@ func cacheFiles(paths []string) ([]*excelize.File, error) {
 func cacheFile(path string) error {
 	fi, err := excelize.OpenFile(path)
 	if err != nil {
-		return fmt.Errorf("error while opening %s: %w", filepath.Base(path), err)
+		return &Error{Kind: ErrFileOpen, File: path, Err: err}
 	}
 
 	if fileCache == nil {
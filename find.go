@@ -12,10 +12,26 @@ const (
 	itemRecordType  = "ITEM"
 )
 
+// GetFields sends fields specified by retrieve, from items in the given files identified by locate, to the given read
+// buffer.
+//
+// By default, any failure aborts the run.  With ContinueOnFileError, failures are instead recorded per file and the
+// remaining files are still processed.
+//
+// Calls to GetFields, GetLayout and Explain are serialised, as they share the package's header and file caches.
+// Option callbacks and the consumer of readBuffer must not call them while the run is in progress.
 func GetFields(files []string, locate []FieldLocation, retrieve []FieldRetrieval, readBuffer chan field, opts ...Option) (err error) {
 	if err := validateParametersForCaching(files, locate, retrieve, readBuffer); err != nil {
 		return fmt.Errorf("error while validating parameters: %w", err)
 	}
+
+	tolerant, partial := continueOnFileErrorFrom(opts...)
+	if partial && tolerant.report == nil {
+		return fmt.Errorf("error while validating parameters: the run report given to ContinueOnFileError is nil")
+	}
+
+	runMu.Lock()
+	defer runMu.Unlock()
 	defer func() {
 		removeHeaderCaches()
 
@@ -25,32 +41,73 @@ func GetFields(files []string, locate []FieldLocation, retrieve []FieldRetrieval
 		}
 	}()
 
-	if err = buildCaches(files); err != nil {
-		return fmt.Errorf("error while building caches: %w", err)
-	}
+	if partial {
+		if files, err = buildCachesTolerantly(files, tolerant.report, opts...); err != nil {
+			return fmt.Errorf("error while building caches: %w", err)
+		}
 
-	if err := validateParametersForSearching(files, locate, retrieve); err != nil {
-		return fmt.Errorf("error while validating parameters: %w", err)
+		files = validateParametersTolerantly(files, locate, retrieve, tolerant.report)
+	} else {
+		if err = buildCaches(files, opts...); err != nil {
+			return fmt.Errorf("error while building caches: %w", err)
+		}
+
+		if err := validateParametersForSearching(files, locate, retrieve); err != nil {
+			return fmt.Errorf("error while validating parameters: %w", err)
+		}
 	}
 
 	var eg errgroup.Group
 
 	for _, file := range files {
 		f := file
-		c := make(chan parseTarget, 2)
-		eg.Go(func() error { return readWorker(f, locate[0], c) })
-		eg.Go(func() error { return parseWorker(f, locate, retrieve, c, readBuffer) })
+		eg.Go(func() error {
+			return tolerate(f, tolerant.report, processFile(f, locate, retrieve, readBuffer, opts...))
+		})
 	}
 
 	if err := eg.Wait(); err != nil {
 		return fmt.Errorf("error while retrieving fields: %w", err)
 	}
 
+	if partial {
+		tolerant.report.succeed(files)
+	}
+
+	return nil
+}
+
+// processFile runs the read and parse workers for the given file, returning the first error encountered by either.
+func processFile(file string, locate []FieldLocation, retrieve []FieldRetrieval, readBuffer chan field, opts ...Option) error {
+	var eg errgroup.Group
+
+	c := make(chan parseTarget, 2)
+	eg.Go(func() error { return readWorker(file, locate[0], c, opts...) })
+	eg.Go(func() error { return parseWorker(file, locate, retrieve, c, readBuffer, opts...) })
+
+	err := eg.Wait()
+	observerFrom(opts...).fileDone(file, err)
+
+	return err
+}
+
+// tolerate records the given error for the given file in the given report and returns nil.  If the report is nil, the
+// error is returned instead.
+func tolerate(file string, report *RunReport, err error) error {
+	if report == nil || err == nil {
+		return err
+	}
+
+	report.fail(file, err)
 	return nil
 }
 
 // buildCaches builds the file and header caches using the given files.
-func buildCaches(files []string) error {
+//
+// Header row options within opts determine how the header row of each file is identified.
+func buildCaches(files []string, opts ...Option) error {
+	headerRule = headerRowRuleFrom(opts...)
+
 	cachedFiles, err := cacheFiles(files)
 	if err != nil {
 		return fmt.Errorf("error while loading files: %w", err)
@@ -96,11 +153,20 @@ func validateParametersForSearching(files []string, locate []FieldLocation, retr
 // validateFieldLocations returns a non-nil error if it detects a fatal error with the given field specs in regards
 // to performing a search.
 func validateFieldLocations(locate []FieldLocation, files []string) error {
+	headerCounts, err := headerCountsIn(files)
+	if err != nil {
+		return err
+	}
+
 	for _, l := range locate {
 		for _, file := range files {
-			_, err := headerIndex(file, l.Header.Key, l.Header.OthersInGroup, l.Header.OnMatch)
+			indices, err := headerIndicesFor(file, l.Header)
 			if err != nil {
-				return fmt.Errorf("error while getting index for header %s in %s: %w", l.Header.Key, filepath.Base(file), err)
+				return &Error{Kind: ErrHeaderNotFound, File: file, SpecID: l.ID, Header: l.Header.describe(), Err: err}
+			}
+
+			if l.Header.addressed() && headerCounts[file] <= indices[0] {
+				return &Error{Kind: ErrOffsetOutOfRange, File: file, SpecID: l.ID, Header: l.Header.describe(), Err: fmt.Errorf("%s exceeds the header count of %d in %s", l.Header.describe(), headerCounts[file], filepath.Base(file))}
 			}
 		}
 	}
@@ -111,35 +177,58 @@ func validateFieldLocations(locate []FieldLocation, files []string) error {
 // validateFieldRetrievals returns a non-nil error if it detects a fatal error with the given field retrievals in regards
 // to performing a search.
 func validateFieldRetrievals(retrieve []FieldRetrieval, files []string) error {
-	headerCounts := make(map[string]int)
-
-	for _, file := range files {
-		c, err := headerCountIn(file)
-		if err != nil {
-			return fmt.Errorf("error while getting number of headers in %s: %w", filepath.Base(file), err)
-		}
-
-		headerCounts[file] = c
+	headerCounts, err := headerCountsIn(files)
+	if err != nil {
+		return err
 	}
 
 	for _, r := range retrieve {
 		for _, file := range files {
 
-			index, err := headerIndex(file, r.Header.Key, r.Header.OthersInGroup, r.Header.OnMatch)
+			indices, err := headerIndicesFor(file, r.Header)
 			if err != nil {
-				return fmt.Errorf("error while getting index for header %s in %s: %w", r.Header.Key, filepath.Base(file), err)
+				return &Error{Kind: ErrHeaderNotFound, File: file, SpecID: r.ID, Header: r.Header.describe(), Err: err}
 			}
 
-			for _, offset := range r.FieldOffsets {
-				if index+offset < 0 {
-					return fmt.Errorf("offset %d for field retrieval with spec ID %s results in a header index of %d", offset, r.ID, index+offset)
-				} else if headerCounts[file] <= index+offset {
-					return fmt.Errorf("offset %d for field retrieval with spec ID %s results in a header index of %d, exceeding the header count of %d in %s", offset, r.ID, index+offset, headerCounts[file], filepath.Base(file))
-				}
+			if r.Header.addressed() && headerCounts[file] <= indices[0] {
+				return &Error{Kind: ErrOffsetOutOfRange, File: file, SpecID: r.ID, Header: r.Header.describe(), Err: fmt.Errorf("%s exceeds the header count of %d in %s", r.Header.describe(), headerCounts[file], filepath.Base(file))}
+			}
 
+			for _, index := range indices {
+				for _, offset := range r.FieldOffsets {
+					if index+offset < 0 {
+						return &Error{Kind: ErrOffsetOutOfRange, File: file, SpecID: r.ID, Header: headerTextAt(file, index), Err: fmt.Errorf("offset %d results in a header index of %d", offset, index+offset)}
+					} else if headerCounts[file] <= index+offset {
+						return &Error{Kind: ErrOffsetOutOfRange, File: file, SpecID: r.ID, Header: headerTextAt(file, index), Err: fmt.Errorf("offset %d results in a header index of %d, exceeding the header count of %d in %s", offset, index+offset, headerCounts[file], filepath.Base(file))}
+					}
+
+					if r.UnitHeader == "" {
+						continue
+					}
+
+					if _, found := siblingHeaderIndex(file, r.UnitHeader, index+offset); !found {
+						return &Error{Kind: ErrHeaderNotFound, File: file, SpecID: r.ID, Header: r.UnitHeader, Err: fmt.Errorf("%s is not in the group of %s at index %d", r.UnitHeader, headerTextAt(file, index+offset), index+offset)}
+					}
+				}
 			}
 		}
 	}
 
 	return nil
 }
+
+// headerCountsIn returns the number of headers in each of the given files.
+func headerCountsIn(files []string) (map[string]int, error) {
+	headerCounts := make(map[string]int)
+
+	for _, file := range files {
+		c, err := headerCountIn(file)
+		if err != nil {
+			return nil, fmt.Errorf("error while getting number of headers in %s: %w", filepath.Base(file), err)
+		}
+
+		headerCounts[file] = c
+	}
+
+	return headerCounts, nil
+}
@@ -2,10 +2,12 @@ package fusereader
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -137,3 +139,139 @@ func validRetrieveSpecOverrideOffsets(o []int) FieldRetrieval {
 
 	return f
 }
+
+func TestGetFieldsForAddressedColumn(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	retrieve := validRetrieveSpec()
+	retrieve.Header = NewHeaderSpecificationAtColumn("I")
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "FREE_FROM -- Free from", got[0].Value())
+		assert.Equal(t, "J2", got[0].Address())
+		assert.Equal(t, "Allergen Type Code", got[0].Header())
+	}
+
+	retrieve.Header = NewHeaderSpecificationAtIndex(500)
+
+	err = GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, make(chan field, 10))
+	assert.NotNil(t, err)
+}
+
+func TestGetFieldsForPatternFanOut(t *testing.T) {
+	header := append(headerRowPrefix(), "Nutrient 1 Type Code", "Nutrient 1 Quantity", "Nutrient 2 Type Code", "Nutrient 2 Quantity")
+	path := newTestFile(t, [][]string{
+		header,
+		{itemRecordType, "ADD", "Y", "", "", "GTIN", "00011110603081", "FAT", "3", "SUGAR", "12"},
+		{itemRecordType, "ADD", "Y", "", "", "GTIN", "00077661003169", "FAT", "4"},
+	})
+
+	retrieve := FieldRetrieval{
+		ID:           "Nutrients",
+		Header:       NewHeaderSpecificationMatching(regexp.MustCompile(`^Nutrient \d+ Type Code$`), true),
+		Field:        FieldSpecification{Matches: func(s string) bool { return s != "" }},
+		FieldOffsets: []int{1},
+	}
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "Nutrient 1 Type Code", got[0].Header())
+		assert.Equal(t, "3", got[0].Value())
+		assert.Equal(t, "I2", got[0].Address())
+		assert.Equal(t, "Nutrient 2 Type Code", got[1].Header())
+		assert.Equal(t, "12", got[1].Value())
+		assert.Equal(t, "K2", got[1].Address())
+	}
+
+	retrieve.Header = HeaderSpecification{MatchHeader: func(s string) bool { return strings.HasPrefix(s, "Nutrient") }, OnMatch: 3}
+
+	c = make(chan field, 10)
+	err = GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got = collectFields(c)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "Nutrient 2 Type Code", got[0].Header())
+	}
+}
+
+func TestGetFieldsResolvesHeadersOncePerFile(t *testing.T) {
+	calls := func(items int) int {
+		rows := [][]string{append(headerRowPrefix(), "Nutrient 1 Type Code", "Nutrient 1 Quantity")}
+		for i := 0; i < items; i++ {
+			rows = append(rows, []string{itemRecordType, "ADD", "Y", "", "", "GTIN", "00011110603081", "FAT", "3"})
+		}
+		path := newTestFile(t, rows)
+
+		n := 0
+		retrieve := FieldRetrieval{
+			ID:           "Nutrients",
+			Header:       HeaderSpecification{MatchHeader: func(s string) bool { n++; return strings.HasPrefix(s, "nutrient") }},
+			Field:        FieldSpecification{Matches: func(s string) bool { return s != "" }},
+			FieldOffsets: []int{1},
+		}
+
+		c := make(chan field, items+1)
+		err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, c, NormalizeHeaders(HeaderNormalization{FoldCase: true}))
+		require.Nil(t, err)
+		close(c)
+		assert.Len(t, collectFields(c), items)
+
+		return n
+	}
+
+	assert.Equal(t, calls(2), calls(20))
+}
+
+func TestGetFieldsForProvenance(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	retrieve := validRetrieveSpec()
+	retrieve.Header.FanOut = true
+	retrieve.Field.Matches = func(s string) bool { return strings.HasPrefix(s, "MILK") }
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "M2", got[0].Address())
+		assert.Equal(t, Provenance{
+			Sheet:           worksheetFSItem,
+			Row:             1,
+			Column:          12,
+			GroupRoot:       10,
+			GroupOccurrence: 2,
+			LocateSpecIDs:   []string{"Location spec 01"},
+			MatchOccurrence: 1,
+			Offset:          1,
+		}, got[0].Provenance())
+	}
+
+	retrieve.Field.Matches = func(s string) bool { return s != "" }
+
+	c = make(chan field, 10)
+	err = GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got = collectFields(c)
+	if assert.Greater(t, len(got), 1) {
+		got[0].Provenance().LocateSpecIDs[0] = "Changed"
+		assert.Equal(t, []string{"Location spec 01"}, got[1].Provenance().LocateSpecIDs)
+	}
+}
@@ -0,0 +1,57 @@
+// Package gln provides normalisation and check digit verification for Global Location Numbers.
+//
+// A GLN is 13 digits, the last of which is a check digit calculated in the same manner as for GTINs.
+package gln
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Kindred87/fusereader/gtin"
+)
+
+var (
+	ErrFormat     = errors.New("invalid GLN format")      // ErrFormat indicates a GLN that is not 13 digits long.
+	ErrCheckDigit = errors.New("invalid GLN check digit") // ErrCheckDigit indicates a GLN whose check digit does not match its other digits.
+)
+
+// Normalize returns the given GLN as 13 digits.  Spaces and hyphens are ignored.
+//
+// The check digit is not verified.  See Validate.
+func Normalize(gln string) (string, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(gln))
+
+	if len(digits) != 13 {
+		return "", fmt.Errorf("%w: %q has %d digits", ErrFormat, gln, len(digits))
+	}
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: %q contains %q", ErrFormat, gln, r)
+		}
+	}
+
+	return digits, nil
+}
+
+// Validate returns the given GLN as 13 digits if its format and check digit are valid.
+func Validate(gln string) (string, error) {
+	n, err := Normalize(gln)
+	if err != nil {
+		return "", err
+	}
+
+	want, _ := gtin.CheckDigit(n[:12])
+	if got := int(n[12] - '0'); got != want {
+		return "", fmt.Errorf("%w: %s ends in %d rather than %d", ErrCheckDigit, gln, got, want)
+	}
+
+	return n, nil
+}
+
+// Valid returns true if the given GLN's format and check digit are valid.
+func Valid(gln string) bool {
+	_, err := Validate(gln)
+	return err == nil
+}
@@ -0,0 +1,37 @@
+package gln
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		gln     string
+		want    string
+		wantErr error
+	}{
+		{name: "Valid", gln: "0614141000012", want: "0614141000012"},
+		{name: "Separators", gln: "061414 100002-9", want: "0614141000029"},
+		{name: "Check digit", gln: "0614141000013", wantErr: ErrCheckDigit},
+		{name: "Length", gln: "614141000012", wantErr: ErrFormat},
+		{name: "Letters", gln: "061414100001X", wantErr: ErrFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Validate(tt.gln)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				assert.False(t, Valid(tt.gln))
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, Valid(tt.gln))
+		})
+	}
+}
@@ -3,6 +3,7 @@ module github.com/Kindred87/fusereader
 go 1.18
 
 require (
+	github.com/emirpasic/gods v1.18.1
 	github.com/stretchr/testify v1.7.1
 	github.com/xuri/excelize/v2 v2.6.0
 	golang.org/x/sync v0.0.0-20220513210516-0976fa681c29
@@ -10,7 +11,6 @@ require (
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/emirpasic/gods v1.18.1 // indirect
 	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/richardlehane/mscfb v1.0.4 // indirect
@@ -0,0 +1,125 @@
+// Package gtin provides normalisation, check digit verification and format conversion for Global Trade Item Numbers.
+//
+// GTINs are written in 8-, 12-, 13- or 14-digit forms, known as GTIN-8, GTIN-12 (UPC-A), GTIN-13 (EAN-13) and GTIN-14.
+// Shorter forms are equivalent to the GTIN-14 obtained by left-padding them with zeros.
+package gtin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrFormat     = errors.New("invalid GTIN format")      // ErrFormat indicates a GTIN that is not 8, 12, 13 or 14 digits long.
+	ErrCheckDigit = errors.New("invalid GTIN check digit") // ErrCheckDigit indicates a GTIN whose check digit does not match its other digits.
+	ErrConversion = errors.New("GTIN cannot be shortened") // ErrConversion indicates a GTIN whose leading digits prevent conversion to a shorter form.
+)
+
+// Normalize returns the given GTIN as 14 digits, padding shorter forms with leading zeros.  Spaces and hyphens are
+// ignored.
+//
+// The check digit is not verified.  See Validate.
+func Normalize(gtin string) (string, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(gtin))
+
+	switch len(digits) {
+	case 8, 12, 13, 14:
+	default:
+		return "", fmt.Errorf("%w: %q has %d digits", ErrFormat, gtin, len(digits))
+	}
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: %q contains %q", ErrFormat, gtin, r)
+		}
+	}
+
+	return strings.Repeat("0", 14-len(digits)) + digits, nil
+}
+
+// CheckDigit returns the check digit for the given GTIN digits, excluding the check digit itself.
+func CheckDigit(body string) (int, error) {
+	sum := 0
+
+	for i := 0; i < len(body); i++ {
+		d := body[len(body)-1-i]
+		if d < '0' || d > '9' {
+			return 0, fmt.Errorf("%w: %q contains %q", ErrFormat, body, d)
+		}
+
+		weight := 1
+		if i%2 == 0 {
+			weight = 3
+		}
+
+		sum += int(d-'0') * weight
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+// Validate returns the given GTIN as 14 digits if its format and check digit are valid.
+func Validate(gtin string) (string, error) {
+	n, err := Normalize(gtin)
+	if err != nil {
+		return "", err
+	}
+
+	want, _ := CheckDigit(n[:13])
+	if got := int(n[13] - '0'); got != want {
+		return "", fmt.Errorf("%w: %s ends in %d rather than %d", ErrCheckDigit, gtin, got, want)
+	}
+
+	return n, nil
+}
+
+// Valid returns true if the given GTIN's format and check digit are valid.
+func Valid(gtin string) bool {
+	_, err := Validate(gtin)
+	return err == nil
+}
+
+// Equal returns true if the given GTINs identify the same item, regardless of form.  Malformed GTINs are never equal.
+func Equal(a, b string) bool {
+	na, err := Normalize(a)
+	if err != nil {
+		return false
+	}
+
+	nb, err := Normalize(b)
+	if err != nil {
+		return false
+	}
+
+	return na == nb
+}
+
+// ToGTIN14 returns the given GTIN as 14 digits.
+func ToGTIN14(gtin string) (string, error) {
+	return Normalize(gtin)
+}
+
+// ToGTIN13 returns the given GTIN as 13 digits, as used by EAN-13.
+func ToGTIN13(gtin string) (string, error) {
+	return shorten(gtin, 13)
+}
+
+// ToGTIN12 returns the given GTIN as 12 digits, as used by UPC-A.
+func ToGTIN12(gtin string) (string, error) {
+	return shorten(gtin, 12)
+}
+
+// shorten returns the given GTIN as the given number of digits, provided the digits removed are zeros.
+func shorten(gtin string, digits int) (string, error) {
+	n, err := Normalize(gtin)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimLeft(n[:14-digits], "0") != "" {
+		return "", fmt.Errorf("%w: %s to %d digits", ErrConversion, gtin, digits)
+	}
+
+	return n[14-digits:], nil
+}
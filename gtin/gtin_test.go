@@ -0,0 +1,84 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		gtin    string
+		want    string
+		wantErr bool
+	}{
+		{name: "GTIN-14", gtin: "00011110603081", want: "00011110603081"},
+		{name: "GTIN-13", gtin: "0011110603081", want: "00011110603081"},
+		{name: "GTIN-12", gtin: "011110603081", want: "00011110603081"},
+		{name: "GTIN-8", gtin: "96385074", want: "00000096385074"},
+		{name: "Separators", gtin: " 0-11110-60308-1 ", want: "00011110603081"},
+		{name: "Length", gtin: "1234567", wantErr: true},
+		{name: "Letters", gtin: "01111060308A", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.gtin)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrFormat), "got %v", err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		gtin    string
+		wantErr error
+	}{
+		{name: "Valid GTIN-14", gtin: "10011110603088"},
+		{name: "Valid GTIN-12", gtin: "011110603081"},
+		{name: "Valid GTIN-8", gtin: "96385074"},
+		{name: "Check digit", gtin: "00011110603082", wantErr: ErrCheckDigit},
+		{name: "Format", gtin: "abc", wantErr: ErrFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Validate(tt.gtin)
+			if tt.wantErr == nil {
+				assert.Nil(t, err)
+				assert.True(t, Valid(tt.gtin))
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			assert.False(t, Valid(tt.gtin))
+		})
+	}
+}
+
+func TestConversion(t *testing.T) {
+	got, err := ToGTIN12("00011110603081")
+	assert.Nil(t, err)
+	assert.Equal(t, "011110603081", got)
+
+	got, err = ToGTIN13("011110603081")
+	assert.Nil(t, err)
+	assert.Equal(t, "0011110603081", got)
+
+	got, err = ToGTIN14("011110603081")
+	assert.Nil(t, err)
+	assert.Equal(t, "00011110603081", got)
+
+	_, err = ToGTIN12("10011110603088")
+	assert.True(t, errors.Is(err, ErrConversion), "got %v", err)
+
+	assert.True(t, Equal("011110603081", "00011110603081"))
+	assert.False(t, Equal("011110603081", "10011110603088"))
+}
@@ -3,6 +3,9 @@ package fusereader
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/emirpasic/gods/trees/avltree"
 	"github.com/xuri/excelize/v2"
@@ -24,32 +27,106 @@ const (
 var (
 	headerCache          map[string]map[string][]int // headerCache contains the header index caches for one or more files.  If all files share the same header indices, then the key used will be the value of sharedHeaderCacheKey.
 	headerGroupRootCache map[string]*avltree.Tree    // headerGroupRootCache stores the header group roots for each file within a binary tree.
+	headerRowCache       map[string]int              // headerRowCache stores the one-based number of the header row for each file.
+	headerTextCache      map[string][]string         // headerTextCache stores the contents of the header row for each file.
+	headerRule           headerRowRule               // headerRule describes how header rows are identified for the current run.
+	runMu                sync.Mutex                  // runMu serialises runs, as each run builds and removes the caches above and closes the file cache.
 )
 
 const (
 	sharedHeaderCacheKey = "shared" // sharedHeaderCacheKey is used as the file key for the header cache in situations where all files contain share the same header indices.
-	headerRowMax         = 5        // headerRowMax describes the maximum number of rows by which the header row should have been found.
+	headerRowMax         = 5        // headerRowMax describes the default maximum number of rows by which the header row should have been found.
+	headerRowPreviewMax  = 4        // headerRowPreviewMax is the number of leading cells per row described when the header row cannot be found.
 )
 
-// isHeaderRow returns true if the given slice contains the prefix expected in a FUSE header row.
-func isHeaderRow(s []string) bool {
-	if len(s) == 0 {
-		return false
+// headerRowRule describes how the header row of a file is identified.
+type headerRowRule struct {
+	scanLimit int              // scanLimit is the maximum number of rows searched for the header row.  A value less than or equal to zero results in headerRowMax being used.
+	required  []string         // required contains headers that must all be present in the header row.  If empty, the row must begin with headerRowPrefix.
+	row       int              // row is the one-based number of the header row.  If greater than zero, the header row is not searched for.
+	names     headerNormalizer // names normalises header text before headers are compared or cached.
+}
+
+// headerRowRuleFrom returns the header row rule described by the given options.
+func headerRowRuleFrom(opts ...Option) headerRowRule {
+	var rule headerRowRule
+
+	if o, ok := headerRowScanLimitFrom(opts...); ok {
+		rule.scanLimit = o.rows
 	}
 
-	prefix := headerRowPrefix()
+	if o, ok := headerRowRequiresFrom(opts...); ok {
+		rule.required = o.headers
+	}
 
-	if len(s) < len(prefix) {
-		return false
+	if o, ok := headerRowAtFrom(opts...); ok {
+		rule.row = o.row
 	}
 
-	for i, p := range prefix {
-		if s[i] != p {
-			return false
+	rule.names = headerNormalizerFrom(opts...)
+
+	return rule
+}
+
+// limit returns the maximum number of rows that will be searched for the header row.
+func (r headerRowRule) limit() int {
+	if r.row > 0 {
+		return r.row
+	}
+
+	if r.scanLimit <= 0 {
+		return headerRowMax
+	}
+
+	return r.scanLimit
+}
+
+// matches returns true if the given row satisfies the rule.  If not, a description of what was found instead is
+// also returned.
+func (r headerRowRule) matches(s []string) (bool, string) {
+	if len(r.required) == 0 {
+		prefix := headerRowPrefix()
+
+		matched := len(s) >= len(prefix)
+		for i := 0; matched && i < len(prefix); i++ {
+			matched = r.names.normalize(s[i]) == r.names.normalize(prefix[i])
 		}
+
+		if matched {
+			return true, ""
+		}
+
+		preview := s
+		if len(preview) > headerRowPreviewMax {
+			preview = preview[:headerRowPreviewMax]
+		}
+
+		return false, fmt.Sprintf("began with %q", preview)
 	}
 
-	return true
+	present := make(map[string]bool)
+	for _, header := range s {
+		present[r.names.normalize(header)] = true
+	}
+
+	var missing []string
+	for _, header := range r.required {
+		if !present[r.names.normalize(header)] {
+			missing = append(missing, header)
+		}
+	}
+
+	if len(missing) == 0 {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("was missing %q", missing)
+}
+
+// isHeaderRow returns true if the given slice contains the prefix expected in a FUSE header row.
+func isHeaderRow(s []string) bool {
+	ok, _ := headerRowRule{}.matches(s)
+	return ok
 }
 
 // headerRowPrefix returns the headers that a FUSE header row should begin with.
@@ -84,6 +161,7 @@ func buildHeaderCaches(files ...*excelize.File) error {
 		headerCache[sharedHeaderCacheKey] = make(map[string][]int)
 
 		for i, header := range headers[0] {
+			header = headerRule.names.normalize(header)
 			headerCache[sharedHeaderCacheKey][header] = append(headerCache[sharedHeaderCacheKey][header], i)
 		}
 
@@ -96,6 +174,7 @@ func buildHeaderCaches(files ...*excelize.File) error {
 			headerCache[file.Path] = make(map[string][]int)
 
 			for j, header := range headers[i] {
+				header = headerRule.names.normalize(header)
 				headerCache[file.Path][header] = append(headerCache[file.Path][header], j)
 			}
 
@@ -112,13 +191,23 @@ func buildHeaderCaches(files ...*excelize.File) error {
 func assembleHeaders(files []*excelize.File) ([][]string, error) {
 	headers := make([][]string, len(files))
 
+	if headerRowCache == nil {
+		headerRowCache = make(map[string]int)
+	}
+
+	if headerTextCache == nil {
+		headerTextCache = make(map[string][]string)
+	}
+
 	for i, file := range files {
-		h, err := headersFrom(file)
+		h, row, err := locateHeaderRow(file, headerRule)
 		if err != nil {
 			return nil, fmt.Errorf("error while getting headers from %s: %w", filepath.Base(file.Path), err)
 		}
 
 		headers[i] = h
+		headerRowCache[file.Path] = row
+		headerTextCache[file.Path] = h
 	}
 
 	return headers, nil
@@ -126,33 +215,54 @@ func assembleHeaders(files []*excelize.File) ([][]string, error) {
 
 // headersFrom returns the contents of the header row in the given file.
 func headersFrom(file *excelize.File) ([]string, error) {
+	headers, _, err := locateHeaderRow(file, headerRule)
+	return headers, err
+}
+
+// locateHeaderRow returns the contents and one-based row number of the header row in the given file, as identified
+// by the given rule.
+//
+// If the header row cannot be found, the returned error describes the rows that were found instead.
+func locateHeaderRow(file *excelize.File, rule headerRowRule) ([]string, int, error) {
 	rows, err := file.Rows(worksheetFSItem)
 	if err != nil {
-		return nil, fmt.Errorf("error while initiating row iterator for %s: %w", filepath.Base(file.Path), err)
+		return nil, 0, fmt.Errorf("error while initiating row iterator for %s: %w", filepath.Base(file.Path), err)
 	}
+	defer rows.Close()
+
+	var found []string
 
 	currRow := 0
 	for rows.Next() {
 		currRow++
 
-		if currRow >= headerRowMax {
-			return nil, fmt.Errorf("could not locate header row in %s", filepath.Base(file.Path))
+		if currRow > rule.limit() {
+			break
+		} else if rule.row > 0 && currRow < rule.row {
+			continue
 		}
 
 		r, err := rows.Columns()
 		if err != nil {
-			return nil, fmt.Errorf("error while reading row %d in %s: %w", currRow, filepath.Base(file.Path), err)
+			return nil, 0, fmt.Errorf("error while reading row %d in %s: %w", currRow, filepath.Base(file.Path), err)
 		}
 
-		if isHeaderRow(r) {
-			return r, nil
+		ok, mismatch := rule.matches(r)
+		if ok || (rule.row > 0 && len(rule.required) == 0) {
+			return r, currRow, nil
 		}
+
+		found = append(found, fmt.Sprintf("row %d %s", currRow, mismatch))
 	}
 
-	return nil, fmt.Errorf("could not locate header row in %s", filepath.Base(file.Path))
+	if len(found) == 0 {
+		return nil, 0, &Error{Kind: ErrHeaderRowNotFound, File: file.Path, Err: fmt.Errorf("could not locate header row in %s within %d rows: the worksheet contains %d rows", filepath.Base(file.Path), rule.limit(), currRow)}
+	}
+
+	return nil, 0, &Error{Kind: ErrHeaderRowNotFound, File: file.Path, Err: fmt.Errorf("could not locate header row in %s within %d rows: %s", filepath.Base(file.Path), rule.limit(), strings.Join(found, "; "))}
 }
 
-// headersAreShared returns true if all of the given headers are identical.
+// headersAreShared returns true if all of the given headers are identical once normalised.
 func headersAreShared(headers [][]string) bool {
 	for _, h := range headers[1:] {
 		if len(h) != len(headers[0]) {
@@ -162,7 +272,7 @@ func headersAreShared(headers [][]string) bool {
 
 	for _, h := range headers[1:] {
 		for i, header := range h {
-			if header != headers[0][i] {
+			if headerRule.names.normalize(header) != headerRule.names.normalize(headers[0][i]) {
 				return false
 			}
 		}
@@ -185,7 +295,7 @@ func buildHeaderGroupRootCache(cacheKey string) error {
 	tree := avltree.NewWithIntComparator()
 	tree.Put(-1, -1) // Because the very first group starts at index 0.
 
-	for _, index := range headerCache[cacheKey][headerNewGroupIndicator] {
+	for _, index := range cachedHeaderIndices(cacheKey, headerNewGroupIndicator) {
 		tree.Put(index, index)
 	}
 
@@ -206,34 +316,112 @@ func removeHeaderCaches() {
 	}
 
 	headerGroupRootCache = nil
+	headerRowCache = nil
+	headerTextCache = nil
+	headerRule = headerRowRule{}
 }
 
 // headerIndex returns the zero-based index of the given key header.
 //
 // matchOn is used in situations where multiple header groups are located to specify which group will be referenced.  With <=1 specifying the first match.
 func headerIndex(file, keyHeader string, otherHeadersInGroup []string, matchOn int) (int, error) {
+	return headerIndexFor(file, HeaderSpecification{Key: keyHeader, OthersInGroup: otherHeadersInGroup, OnMatch: matchOn})
+}
+
+// headerIndexFor returns the zero-based index of the key header described by the given specification.
+//
+// For specifications that fan out, the first index is returned.
+func headerIndexFor(file string, spec HeaderSpecification) (int, error) {
+	indices, err := headerIndicesFor(file, spec)
+	if err != nil {
+		return 0, err
+	}
+
+	return indices[0], nil
+}
+
+// headerIndicesFor returns the zero-based indices of the key headers described by the given specification, in
+// ascending order.
+//
+// A single index is returned unless the specification fans out.
+func headerIndicesFor(file string, spec HeaderSpecification) ([]int, error) {
+	if spec.addressed() {
+		index, err := spec.columnIndex()
+		if err != nil {
+			return nil, err
+		}
+
+		return []int{index}, nil
+	} else if spec.selectsByText() {
+		return selectedHeaderIndices(file, spec)
+	}
+
 	if _, exist := headerCache[sharedHeaderCacheKey]; exist {
 		file = sharedHeaderCacheKey
 	}
 
-	root, err := headerGroupRootIndex(file, append(otherHeadersInGroup, keyHeader), matchOn)
+	headersInGroup := append([]string{spec.Key}, spec.OthersInGroup...)
+
+	roots, err := constrainedGroupRoots(file, headersInGroup, spec.Order)
 	if err != nil {
-		return 0, fmt.Errorf("error while getting index of group root for %s and %#v: %w", keyHeader, otherHeadersInGroup, err)
+		return nil, fmt.Errorf("error while getting index of group root for %s and %#v: %w", spec.Key, spec.OthersInGroup, err)
 	}
 
-	tree := avltree.NewWithIntComparator()
+	if !spec.FanOut {
+		root, err := nthGroupRoot(roots, spec.OnMatch, headersInGroup)
+		if err != nil {
+			return nil, fmt.Errorf("error while getting index of group root for %s and %#v: %w", spec.Key, spec.OthersInGroup, err)
+		}
 
-	for _, index := range headerCache[file][keyHeader] {
-		tree.Put(index, index)
+		roots = []int{root}
 	}
 
-	v, found := tree.Ceiling(root)
-	tree.Clear()
-	if found {
-		return v.Key.(int), nil
+	var indices []int
+
+	for _, root := range roots {
+		index, found := headerIndexInGroup(file, spec.Key, root)
+		if !found {
+			return nil, fmt.Errorf("unable to determine index for %s in group containing %#v", spec.Key, spec.OthersInGroup)
+		}
+
+		indices = append(indices, index)
 	}
 
-	return 0, fmt.Errorf("unable to determine index for %s in group containing %#v", keyHeader, otherHeadersInGroup)
+	return indices, nil
+}
+
+// selectedHeaderIndices returns the zero-based indices of the headers in the given file whose text is selected by the
+// given specification's pattern or predicate.
+func selectedHeaderIndices(file string, spec HeaderSpecification) ([]int, error) {
+	headers, exist := headerTextCache[file]
+	if !exist {
+		return nil, fmt.Errorf("the headers of %s have not been cached", filepath.Base(file))
+	}
+
+	selector := spec
+	selector.Pattern = headerRule.names.pattern(spec.Pattern)
+
+	var indices []int
+
+	for i, header := range headers {
+		if selector.selects(headerRule.names.normalize(header)) {
+			indices = append(indices, i)
+		}
+	}
+
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no %s were found in %s", spec.describe(), filepath.Base(file))
+	} else if spec.FanOut {
+		return indices, nil
+	}
+
+	if spec.OnMatch <= 1 {
+		return indices[:1], nil
+	} else if spec.OnMatch > len(indices) {
+		return nil, fmt.Errorf("match %d was requested, but only %d %s were found in %s", spec.OnMatch, len(indices), spec.describe(), filepath.Base(file))
+	}
+
+	return indices[spec.OnMatch-1 : spec.OnMatch], nil
 }
 
 // headerGroupRootIndex returns the zero-based index of the root of the group containing the given headers from the
@@ -241,67 +429,140 @@ func headerIndex(file, keyHeader string, otherHeadersInGroup []string, matchOn i
 //
 // matchOn is used in situations where multiple header groups are located to specify which group will be referenced.  With <=1 specifying the first match.
 func headerGroupRootIndex(file string, headersInGroup []string, matchOn int) (int, error) {
+	return constrainedGroupRootIndex(file, headersInGroup, nil, matchOn)
+}
+
+// constrainedGroupRootIndex returns the zero-based index of the root of the group containing every one of the given
+// headers from the given file, with the headers positioned as described by order.
+//
+// matchOn is used in situations where multiple header groups are located to specify which group will be referenced.  With <=1 specifying the first match.
+func constrainedGroupRootIndex(file string, headersInGroup []string, order []HeaderOrder, matchOn int) (int, error) {
+	roots, err := constrainedGroupRoots(file, headersInGroup, order)
+	if err != nil {
+		return 0, err
+	}
+
+	return nthGroupRoot(roots, matchOn, headersInGroup)
+}
+
+// nthGroupRoot returns the root referenced by matchOn from the given ascending group roots.  With <=1 specifying the
+// first root.
+func nthGroupRoot(roots []int, matchOn int, headersInGroup []string) (int, error) {
+	if matchOn <= 1 {
+		return roots[0], nil
+	} else if matchOn > len(roots) {
+		return 0, fmt.Errorf("match %d was requested, but only %d groups contain %#v", matchOn, len(roots), headersInGroup)
+	}
+
+	return roots[matchOn-1], nil
+}
+
+// constrainedGroupRoots returns the zero-based indices of the roots of every group containing every one of the given
+// headers from the given file, with the headers positioned as described by order.  The roots are returned in
+// ascending order.
+func constrainedGroupRoots(file string, headersInGroup []string, order []HeaderOrder) ([]int, error) {
 	if headerCache == nil {
-		return 0, fmt.Errorf("header cache is nil")
+		return nil, fmt.Errorf("header cache is nil")
 	} else if headerGroupRootCache == nil {
-		return 0, fmt.Errorf("header group root cache is nil")
+		return nil, fmt.Errorf("header group root cache is nil")
 	}
 
 	if _, exist := headerCache[sharedHeaderCacheKey]; exist {
 		file = sharedHeaderCacheKey
 	} else if _, exist := headerCache[file]; !exist {
-		return 0, fmt.Errorf("file %s does not exist in the header cache", filepath.Base(file))
+		return nil, fmt.Errorf("file %s does not exist in the header cache", filepath.Base(file))
+	}
+
+	headersInGroup = append([]string{}, headersInGroup...)
+	for _, o := range order {
+		headersInGroup = append(headersInGroup, o.Before, o.After)
 	}
 
-	commonIndices := avltree.NewWithIntComparator()
+	var common map[int]bool
 
 	for i, header := range headersInGroup {
 		indices, err := headerGroupRootIndices(file, header)
 		if err != nil {
-			return 0, fmt.Errorf("could not locate %s among the given headers", header)
-		}
-
-		matched := false
-		if i == 0 {
-			matched = true
+			return nil, fmt.Errorf("error while getting group roots for %s: %w", header, err)
+		} else if len(indices) == 0 {
+			return nil, fmt.Errorf("could not locate %s among the given headers", header)
 		}
 
+		roots := make(map[int]bool)
 		for _, index := range indices {
-			if _, exist := commonIndices.Get(index); exist {
-				matched = true
-			} else {
-				commonIndices.Put(index, 0)
+			if i == 0 || common[index] {
+				roots[index] = true
 			}
+		}
 
-			n := commonIndices.GetNode(index)
-			v, _ := n.Value.(int)
-			v++
+		if len(roots) == 0 {
+			return nil, fmt.Errorf("%s does not share a group with %#v", header, headersInGroup[:i])
+		}
+
+		common = roots
+	}
 
-			commonIndices.Remove(index)
-			commonIndices.Put(index, v)
+	for _, o := range order {
+		for root := range common {
+			before, _ := headerIndexInGroup(file, o.Before, root)
+			after, _ := headerIndexInGroup(file, o.After, root)
+
+			if before >= after {
+				delete(common, root)
+			}
 		}
 
-		if !matched {
-			return 0, fmt.Errorf("could not find common group for %s and %#v", header, headersInGroup[:i])
+		if len(common) == 0 {
+			return nil, fmt.Errorf("%s does not precede %s in any group containing %#v", o.Before, o.After, headersInGroup)
 		}
 	}
 
-	matches := 0
-	for _, k := range commonIndices.Keys() {
-		n := commonIndices.GetNode(k)
-		v, _ := n.Value.(int)
+	sorted := make([]int, 0, len(common))
+	for root := range common {
+		sorted = append(sorted, root)
+	}
+	sort.Ints(sorted)
+
+	return sorted, nil
+}
 
-		if v == 2 {
-			matches++
+// headerIndexInGroup returns the zero-based index of the first instance of the given header within the group with the
+// given root, within the header cache of the given key.
+func headerIndexInGroup(cacheKey, header string, root int) (int, bool) {
+	for _, index := range cachedHeaderIndices(cacheKey, header) {
+		node, found := headerGroupRootCache[cacheKey].Floor(index)
+		if found && node.Key.(int) == root {
+			return index, true
 		}
+	}
+
+	return 0, false
+}
+
+// siblingHeaderIndex returns the zero-based index of the first instance of the given header within the same group as
+// the header at the given zero-based index of the given file.
+func siblingHeaderIndex(file, header string, index int) (int, bool) {
+	key := file
+	if _, exist := headerCache[sharedHeaderCacheKey]; exist {
+		key = sharedHeaderCacheKey
+	}
 
-		if matches >= matchOn {
-			keyAsInt, _ := k.(int)
-			return keyAsInt, nil
+	root := groupRootOf(file, index)
+
+	for _, i := range cachedHeaderIndices(key, header) {
+		if groupRootOf(file, i) == root {
+			return i, true
 		}
 	}
 
-	return 0, fmt.Errorf("unable to determine index for group containing %#v", headersInGroup)
+	return 0, false
+}
+
+// cachedHeaderIndices returns the zero-based indices of the given header within the header cache of the given key.
+//
+// The header is normalised in the same manner as the cached headers.
+func cachedHeaderIndices(cacheKey, header string) []int {
+	return headerCache[cacheKey][headerRule.names.normalize(header)]
 }
 
 // headerGroupRootIndices returns the group root indices that the given header belongs to, within the header cache
@@ -309,7 +570,7 @@ func headerGroupRootIndex(file string, headersInGroup []string, matchOn int) (in
 func headerGroupRootIndices(cacheKey string, header string) ([]int, error) {
 	var indices []int
 
-	for _, index := range headerCache[cacheKey][header] {
+	for _, index := range cachedHeaderIndices(cacheKey, header) {
 		node, found := headerGroupRootCache[cacheKey].Floor(index)
 		if !found {
 			return nil, fmt.Errorf("could not locate group root for %s at index %d", header, index)
@@ -321,6 +582,36 @@ func headerGroupRootIndices(cacheKey string, header string) ([]int, error) {
 	return indices, nil
 }
 
+// headerTextAt returns the text of the header at the given zero-based index in the given file.
+//
+// If the file's headers have not been cached or the index is out of range, an empty string is returned.
+func headerTextAt(file string, index int) string {
+	headers := headerTextCache[file]
+
+	if index < 0 || index >= len(headers) {
+		return ""
+	}
+
+	return headers[index]
+}
+
+// headerOccurrence returns the one-based occurrence of the header at the given zero-based index of the given file
+// among headers of the same name, or zero if the header is not cached.
+func headerOccurrence(file string, index int) int {
+	key := file
+	if _, exist := headerCache[sharedHeaderCacheKey]; exist {
+		key = sharedHeaderCacheKey
+	}
+
+	for i, cached := range cachedHeaderIndices(key, headerTextAt(file, index)) {
+		if cached == index {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
 // headerCountIn returns the number of headers in the given file.
 //
 // If the given file is not already cached, an error will be returned.
@@ -201,3 +201,97 @@ func Test_headerCountIn(t *testing.T) {
 		})
 	}
 }
+
+func Test_locateHeaderRow(t *testing.T) {
+	banner := []string{"Publication Date", "2022-06-01"}
+	alternate := []string{"RECORD TYPE", "Item ID", "Item Type"}
+
+	tests := []struct {
+		name      string
+		rows      [][]string
+		rule      headerRowRule
+		want      int
+		wantErr   bool
+		errPhrase string
+	}{
+		{name: "Default", rows: [][]string{banner, headerRowPrefix()}, want: 2},
+		{name: "Fifth row", rows: [][]string{banner, banner, banner, banner, headerRowPrefix()}, want: 5},
+		{name: "Beyond default", rows: [][]string{banner, banner, banner, banner, banner, headerRowPrefix()}, wantErr: true, errPhrase: "row 1 began with"},
+		{name: "Scan limit", rows: [][]string{banner, banner, banner, banner, banner, headerRowPrefix()}, rule: headerRowRule{scanLimit: 6}, want: 6},
+		{name: "Required set", rows: [][]string{banner, alternate}, rule: headerRowRule{required: []string{"Item ID", "RECORD TYPE"}}, want: 2},
+		{name: "Required set missing", rows: [][]string{banner, alternate}, rule: headerRowRule{required: []string{"Item ID", "OPERATION"}}, wantErr: true, errPhrase: `missing ["OPERATION"]`},
+		{name: "Explicit row", rows: [][]string{banner, alternate, headerRowPrefix()}, rule: headerRowRule{row: 2}, want: 2},
+		{name: "Explicit row beyond sheet", rows: [][]string{banner}, rule: headerRowRule{row: 3}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fi, err := excelize.OpenFile(newTestFile(t, tt.rows))
+			assert.Nil(t, err)
+			defer fi.Close()
+
+			_, got, err := locateHeaderRow(fi, tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("locateHeaderRow() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				assert.Contains(t, err.Error(), tt.errPhrase)
+			}
+			if got != tt.want {
+				t.Errorf("locateHeaderRow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_constrainedGroupRootIndex(t *testing.T) {
+	row := headerRowPrefix()
+	row = append(row, headerNewGroupIndicator, "A", "B")      // Root 7
+	row = append(row, headerNewGroupIndicator, "A", "C")      // Root 10
+	row = append(row, headerNewGroupIndicator, "A", "B", "C") // Root 13
+	row = append(row, headerNewGroupIndicator, "C", "B", "A") // Root 17
+
+	path := newTestFile(t, [][]string{row})
+
+	cachedFiles, err := cacheFiles([]string{path})
+	defer closeFiles()
+	assert.Nil(t, err)
+
+	err = buildHeaderCaches(cachedFiles...)
+	defer removeHeaderCaches()
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name      string
+		headers   []string
+		order     []HeaderOrder
+		matchOn   int
+		want      int
+		wantErr   bool
+		errPhrase string
+	}{
+		{name: "Pair", headers: []string{"A", "C"}, matchOn: 1, want: 10},
+		{name: "Three headers", headers: []string{"A", "B", "C"}, matchOn: 1, want: 13},
+		{name: "Three headers second", headers: []string{"A", "B", "C"}, matchOn: 2, want: 17},
+		{name: "Three headers third", headers: []string{"A", "B", "C"}, matchOn: 3, wantErr: true, errPhrase: "only 2 groups"},
+		{name: "Ordered", headers: []string{"A", "B", "C"}, order: []HeaderOrder{{Before: "C", After: "A"}}, matchOn: 1, want: 17},
+		{name: "Order unsatisfied", headers: []string{"A", "B"}, order: []HeaderOrder{{Before: "B", After: "C"}, {Before: "C", After: "A"}}, wantErr: true, errPhrase: "C does not precede A"},
+		{name: "No shared group", headers: []string{"A", "B", headerItemID}, wantErr: true, errPhrase: "Item ID does not share a group"},
+		{name: "Missing header", headers: []string{"A", "D"}, wantErr: true, errPhrase: "could not locate D"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := constrainedGroupRootIndex(path, tt.headers, tt.order, tt.matchOn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("constrainedGroupRootIndex() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				assert.Contains(t, err.Error(), tt.errPhrase)
+			}
+			if got != tt.want {
+				t.Errorf("constrainedGroupRootIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
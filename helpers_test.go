@@ -1,4 +1,64 @@
 package fusereader
 
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
 // fuseTestFiles contains the testdata/ paths for the fuse files.
 var fuseTestFiles = []string{"testdata/fuse01.xlsx", "testdata/fuse02.xlsx", "testdata/fuse03.xlsx"}
+
+// newTestFile writes the given rows to the FS_Item worksheet of a new workbook within a temporary directory and
+// returns the path of the workbook.
+func newTestFile(t *testing.T, rows [][]string) string {
+	t.Helper()
+
+	f := excelize.NewFile()
+	f.NewSheet(worksheetFSItem)
+	f.DeleteSheet("Sheet1")
+
+	for i, row := range rows {
+		r := row
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := f.SetSheetRow(worksheetFSItem, cell, &r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "fuse.xlsx")
+	if err := f.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// itemTestRows returns the rows of a small FUSE worksheet containing a header row and three items.
+func itemTestRows() [][]string {
+	header := groupedHeaderRow(2)
+
+	return [][]string{
+		header,
+		{itemRecordType, "ADD", "Y", "0614141000012", "Acme", "GTIN", "00011110603081", "", "SOYBEANS -- Soybeans", "FREE_FROM -- Free from", "", "MILK -- Milk", "CONTAINS -- Contains"},
+		{"", "", "", "", "", "", "", "", "PEANUTS -- Peanuts", "MAY_CONTAIN -- May contain"},
+		{itemRecordType, "CHANGE", "N", "0614141000029", "Globex", "GTIN", "10011110603088", "", "WHEAT -- Wheat", "CONTAINS -- Contains"},
+		{itemRecordType, "ADD", "Y", "0614141000012", "Acme", "GTIN", "00077661003169", "", "SOYBEANS -- Soybeans", "CONTAINS -- Contains"},
+	}
+}
+
+// collectFields returns every field received from the given buffer until it is closed.
+func collectFields(buf chan field) []field {
+	var out []field
+
+	for f := range buf {
+		out = append(out, f)
+	}
+
+	return out
+}
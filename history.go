@@ -0,0 +1,163 @@
+package fusereader
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// AttributeSelector identifies an attribute of an item whose history is of interest.
+type AttributeSelector struct {
+	ItemID     string `json:"itemID"`     // ItemID is the item's Item ID.  GTINs are compared regardless of form.
+	Header     string `json:"header"`     // Header is the text of the attribute's header.
+	Occurrence int    `json:"occurrence"` // Occurrence is the one-based occurrence of the header among headers of the same name, which for repeated groups is the group occurrence.  Zero selects every occurrence.
+}
+
+// AttributeHistory is the timeline of an attribute's values across a series of files.
+type AttributeHistory struct {
+	Selector AttributeSelector `json:"selector"` // Selector identifies the attribute.
+	Entries  []HistoryEntry    `json:"entries"`  // Entries contains the attribute's values in each file containing the item, in file and then row order.
+}
+
+// Histories contains the timelines returned by History, one per selector.
+type Histories []AttributeHistory
+
+// HistoryEntry describes an attribute's values within a single file.
+type HistoryEntry struct {
+	File    string   `json:"file"`    // File is the path of the file.
+	Row     int      `json:"row"`     // Row is the one-based row number of the item's first row.
+	Values  []string `json:"values"`  // Values contains the attribute's non-empty values, in row and then column order.
+	Cells   []string `json:"cells"`   // Cells contains the address of each value in A1 format.
+	Changed bool     `json:"changed"` // Changed is true if the values differ from those of the previous entry, or if this is the first entry.
+}
+
+// Value returns the entry's values joined by semicolons.
+func (e HistoryEntry) Value() string {
+	return strings.Join(e.Values, "; ")
+}
+
+// LastChanged returns the most recent entry in which the attribute's values changed.  The returned boolean is false if
+// the item was not found in any file.
+func (h AttributeHistory) LastChanged() (HistoryEntry, bool) {
+	for i := len(h.Entries) - 1; i >= 0; i-- {
+		if h.Entries[i].Changed {
+			return h.Entries[i], true
+		}
+	}
+
+	return HistoryEntry{}, false
+}
+
+// String returns a human-readable timeline of the attribute's values.
+func (h AttributeHistory) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s", h.Selector.ItemID, h.Selector.Header)
+	if h.Selector.Occurrence > 0 {
+		fmt.Fprintf(&b, " #%d", h.Selector.Occurrence)
+	}
+	b.WriteString("\n")
+
+	for _, e := range h.Entries {
+		marker := " "
+		if e.Changed {
+			marker = "*"
+		}
+
+		fmt.Fprintf(&b, "  %s %s row %d: %q\n", marker, filepath.Base(e.File), e.Row, e.Value())
+	}
+
+	return b.String()
+}
+
+// History returns the timeline of values of each selected attribute across the given files, which should be ordered
+// from oldest to newest.
+//
+// Each file is read in a single streaming pass, regardless of the number of selectors.
+func History(files []string, selectors []AttributeSelector, opts ...Option) (Histories, error) {
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("no selectors were given")
+	}
+
+	histories := make(Histories, len(selectors))
+	byItem := make(map[string][]int)
+
+	for i, s := range selectors {
+		histories[i] = AttributeHistory{Selector: s, Entries: []HistoryEntry{}}
+		byItem[itemKey(s.ItemID)] = append(byItem[itemKey(s.ItemID)], i)
+	}
+
+	err := readItems(files, func(it Item) error {
+		for _, i := range byItem[itemKey(it.ID)] {
+			values, cells := attributeValues(it, selectors[i].Header, selectors[i].Occurrence)
+			entry := HistoryEntry{File: it.File, Row: it.BeginningRow, Values: append([]string{}, values...), Cells: append([]string{}, cells...), Changed: true}
+
+			if n := len(histories[i].Entries); n > 0 {
+				entry.Changed = !equalValues(histories[i].Entries[n-1].Values, values)
+			}
+
+			histories[i].Entries = append(histories[i].Entries, entry)
+		}
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading items: %w", err)
+	}
+
+	return histories, nil
+}
+
+// JSON returns the histories encoded as indented JSON.
+func (h Histories) JSON() ([]byte, error) {
+	return json.MarshalIndent(h, "", "  ")
+}
+
+// attributeValues returns the non-empty values, and their addresses, under the given occurrence of the given header
+// within the given item.  An occurrence of zero selects every occurrence.
+func attributeValues(it Item, header string, occurrence int) ([]string, []string) {
+	var columns []int
+
+	header = it.names.normalize(header)
+	seen := 0
+
+	for i, h := range it.Headers {
+		if it.names.normalize(h) != header {
+			continue
+		}
+
+		seen++
+		if occurrence == 0 || occurrence == seen {
+			columns = append(columns, i)
+		}
+	}
+
+	var values, cells []string
+
+	for r, row := range it.Rows {
+		for _, c := range columns {
+			if v := cellAt(row, c); v != "" {
+				values = append(values, v)
+				cells = append(cells, cellName(c, it.BeginningRow+r))
+			}
+		}
+	}
+
+	return values, cells
+}
+
+// equalValues returns true if the given value slices contain the same values in the same order.
+func equalValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
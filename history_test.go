@@ -0,0 +1,58 @@
+package fusereader
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	week1 := newTestFile(t, itemTestRows())
+
+	rows := itemTestRows()
+	week2 := newTestFile(t, rows)
+
+	rows[1][12] = "MAY_CONTAIN -- May contain"
+	week3 := newTestFile(t, rows)
+
+	selectors := []AttributeSelector{
+		{ItemID: "011110603081", Header: "Level Of Containment", Occurrence: 2},
+		{ItemID: "00011110603081", Header: "Allergen Type Code"},
+		{ItemID: "00000000000000", Header: "Allergen Type Code"},
+	}
+
+	got, err := History([]string{week1, week2, week3}, selectors)
+	require.Nil(t, err)
+	require.Len(t, got, 3)
+
+	if assert.Len(t, got[0].Entries, 3) {
+		assert.Equal(t, []bool{true, false, true}, []bool{got[0].Entries[0].Changed, got[0].Entries[1].Changed, got[0].Entries[2].Changed})
+		assert.Equal(t, []string{"M2"}, got[0].Entries[2].Cells)
+
+		last, ok := got[0].LastChanged()
+		assert.True(t, ok)
+		assert.Equal(t, week3, last.File)
+		assert.Equal(t, "MAY_CONTAIN -- May contain", last.Value())
+	}
+
+	if assert.Len(t, got[1].Entries, 3) {
+		assert.Equal(t, []string{"SOYBEANS -- Soybeans", "MILK -- Milk", "PEANUTS -- Peanuts"}, got[1].Entries[0].Values)
+
+		last, _ := got[1].LastChanged()
+		assert.Equal(t, week1, last.File)
+	}
+
+	assert.Empty(t, got[2].Entries)
+	_, ok := got[2].LastChanged()
+	assert.False(t, ok)
+
+	b, err := got.JSON()
+	require.Nil(t, err)
+	assert.True(t, json.Valid(b))
+	assert.NotContains(t, string(b), "null")
+
+	_, err = History([]string{week1}, nil)
+	assert.NotNil(t, err)
+}
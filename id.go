@@ -5,4 +5,21 @@ type optionID int
 const (
 	idCacheInMemory optionID = iota
 	idCacheOnDisk
+	idHeaderRowScanLimit
+	idHeaderRowRequires
+	idHeaderRowAt
+	idNormalizeHeaders
+	idHeaderAliases
+	idSegmentItems
+	idContinueOnFileError
+	idReportWarnings
+	idExplainItem
+	idLogTo
+	idReportProgress
+	idValidateCodes
+	idFlagInvalidGTINs
+	idFilterProviders
+	idImportFlaggedOnly
+	idFilterOperations
+	idKeyDuplicatesByProvider
 )
@@ -0,0 +1,125 @@
+package fusereader
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// FileMetadata describes the publication details of a FUSE file.
+type FileMetadata struct {
+	Path          string                  // Path is the path of the file.
+	HeaderRow     int                     // HeaderRow is the one-based number of the header row.
+	Entries       []MetadataEntry         // Entries contains the key/value pairs parsed from the rows above the header row.
+	PreHeaderRows [][]string              // PreHeaderRows contains the contents of the rows above the header row.
+	Properties    *excelize.DocProperties // Properties contains the document properties of the workbook.
+}
+
+// MetadataEntry is a key/value pair from the rows above the header row.
+type MetadataEntry struct {
+	Key     string // Key is the name of the entry.
+	Value   string // Value is the value of the entry, which may be empty.
+	Address string // Address is the address of the cell containing the key, in A1 format.
+}
+
+// Get returns the value of the first entry with the given key.  Keys are compared without regard to case.
+func (m FileMetadata) Get(key string) (string, bool) {
+	for _, e := range m.Entries {
+		if strings.EqualFold(e.Key, key) {
+			return e.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// FileInfo returns the header row number, pre-header metadata, and document properties of the given file.
+//
+// Header row options determine how the header row is identified.
+func FileInfo(path string, opts ...Option) (FileMetadata, error) {
+	fi, err := excelize.OpenFile(path)
+	if err != nil {
+		return FileMetadata{}, &Error{Kind: ErrFileOpen, File: path, Err: err}
+	}
+	defer fi.Close()
+
+	_, headerRow, err := locateHeaderRow(fi, headerRowRuleFrom(opts...))
+	if err != nil {
+		return FileMetadata{}, fmt.Errorf("error while locating header row: %w", err)
+	}
+
+	props, err := fi.GetDocProps()
+	if err != nil {
+		return FileMetadata{}, fmt.Errorf("error while getting document properties of %s: %w", filepath.Base(path), err)
+	}
+
+	out := FileMetadata{Path: path, HeaderRow: headerRow, Properties: props}
+
+	rows, err := fi.Rows(worksheetFSItem)
+	if err != nil {
+		return FileMetadata{}, fmt.Errorf("error while initiating row iterator for %s: %w", filepath.Base(path), err)
+	}
+	defer rows.Close()
+
+	for currRow := 1; currRow < headerRow && rows.Next(); currRow++ {
+		r, err := rows.Columns()
+		if err != nil {
+			return FileMetadata{}, fmt.Errorf("error while reading row %d in %s: %w", currRow, filepath.Base(path), err)
+		}
+
+		out.PreHeaderRows = append(out.PreHeaderRows, r)
+
+		entries, err := metadataEntries(r, currRow)
+		if err != nil {
+			return FileMetadata{}, fmt.Errorf("error while parsing row %d in %s: %w", currRow, filepath.Base(path), err)
+		}
+
+		out.Entries = append(out.Entries, entries...)
+	}
+
+	return out, nil
+}
+
+// metadataEntries parses the given pre-header row into key/value pairs.
+//
+// A cell of the form "Key: Value" is a pair on its own.  Otherwise, a cell is treated as a key and the next non-empty
+// cell in the row as its value.
+func metadataEntries(row []string, rowNum int) ([]MetadataEntry, error) {
+	var out []MetadataEntry
+
+	for i := 0; i < len(row); i++ {
+		cell := strings.TrimSpace(row[i])
+		if cell == "" {
+			continue
+		}
+
+		address, err := excelize.CoordinatesToCellName(i+1, rowNum)
+		if err != nil {
+			return nil, fmt.Errorf("error while converting column %d and row %d to a cell name: %w", i+1, rowNum, err)
+		}
+
+		entry := MetadataEntry{Address: address}
+
+		if k, v, found := strings.Cut(cell, ": "); found {
+			entry.Key, entry.Value = strings.TrimSpace(k), strings.TrimSpace(v)
+			out = append(out, entry)
+			continue
+		}
+
+		entry.Key = strings.TrimSuffix(cell, ":")
+
+		for j := i + 1; j < len(row); j++ {
+			if v := strings.TrimSpace(row[j]); v != "" {
+				entry.Value = v
+				i = j
+				break
+			}
+		}
+
+		out = append(out, entry)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package fusereader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileInfo(t *testing.T) {
+	path := newTestFile(t, [][]string{
+		{"Publication Date", "", "2022-06-01"},
+		{"Export Version: 3.2", "Source:", "FUSE"},
+		headerRowPrefix(),
+	})
+
+	got, err := FileInfo(path)
+	require.Nil(t, err)
+
+	assert.Equal(t, 3, got.HeaderRow)
+	assert.Len(t, got.PreHeaderRows, 2)
+	assert.NotNil(t, got.Properties)
+
+	want := []MetadataEntry{
+		{Key: "Publication Date", Value: "2022-06-01", Address: "A1"},
+		{Key: "Export Version", Value: "3.2", Address: "A2"},
+		{Key: "Source", Value: "FUSE", Address: "B2"},
+	}
+	assert.Equal(t, want, got.Entries)
+
+	v, ok := got.Get("export version")
+	assert.True(t, ok)
+	assert.Equal(t, "3.2", v)
+
+	_, err = FileInfo(path, HeaderRowScanLimit(2))
+	assert.NotNil(t, err)
+}
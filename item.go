@@ -0,0 +1,151 @@
+package fusereader
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Item represents a single item within a FUSE file, comprising the row beginning the item and any continuation rows.
+type Item struct {
+	ID           string           `json:"id"`           // ID is the item's Item ID.
+	File         string           `json:"file"`         // File is the path of the file the item was read from.
+	Operation    Operation        `json:"operation"`    // Operation is the item's OPERATION.
+	BeginningRow int              `json:"beginningRow"` // BeginningRow is the one-based row number of the item's first row.
+	Headers      []string         `json:"headers"`      // Headers contains the text of the file's headers, in column order.
+	Rows         [][]string       `json:"rows"`         // Rows contains the contents of the item's rows.
+	names        headerNormalizer // names normalises headers given to the item's accessors.
+}
+
+// Values returns every non-empty value under the given header, in row and then column order.
+//
+// Headers are compared using the header normalisation in effect when the item was read.
+func (it Item) Values(header string) []string {
+	values, _ := attributeValues(it, header, 0)
+	return values
+}
+
+// Value returns the first non-empty value under the given header, or an empty string if there is none.
+func (it Item) Value(header string) string {
+	values := it.Values(header)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// CodeValues returns every non-empty value under the given header, split into code and description.
+func (it Item) CodeValues(header string) []CodeValue {
+	var out []CodeValue
+
+	for _, v := range it.Values(header) {
+		out = append(out, ParseCodeValue(v))
+	}
+
+	return out
+}
+
+// CodeValue returns the first non-empty value under the given header, split into code and description.
+func (it Item) CodeValue(header string) CodeValue {
+	return ParseCodeValue(it.Value(header))
+}
+
+// readItems reads every item within the given files in a single pass per file, calling fn with each item in order.
+//
+// Files are opened, read and closed one at a time in the order given, so that only one file is held in memory.  As fn is called from the consumer side of the reader, it may take
+// as long as it needs without timing out the reader.  If fn returns an error, reading stops and the error is returned.
+//
+// Header row, header normalisation, item boundary and item filter options apply as they do to GetFields, so items
+// rejected by FilterProviders, ImportFlaggedOnly or FilterOperations are never passed to fn.
+//
+// As with GetFields, calls are serialised with other runs, so fn must not call GetFields, GetLayout or Explain.
+func readItems(files []string, fn func(Item) error, opts ...Option) (err error) {
+	if len(files) == 0 {
+		return fmt.Errorf("no files were given")
+	} else if fn == nil {
+		return fmt.Errorf("the item function is nil")
+	}
+	runMu.Lock()
+	defer runMu.Unlock()
+
+	for _, file := range files {
+		if err := readItemsInFile(file, fn, opts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readItemsInFile caches the given file, reads its items with readItemsIn and then removes it from the caches.
+func readItemsInFile(file string, fn func(Item) error, opts ...Option) (err error) {
+	defer func() {
+		removeHeaderCaches()
+
+		cErr := closeFiles()
+		if err == nil && cErr != nil {
+			err = fmt.Errorf("error while closing %s: %w", filepath.Base(file), cErr)
+		}
+	}()
+
+	if err = buildCaches([]string{file}, opts...); err != nil {
+		return fmt.Errorf("error while building caches: %w", err)
+	}
+
+	err = readItemsIn(file, fn, opts...)
+	observerFrom(opts...).fileDone(file, err)
+
+	if err != nil {
+		return fmt.Errorf("error while reading items in %s: %w", filepath.Base(file), err)
+	}
+
+	return nil
+}
+
+// readItemsIn reads every item within the given cached file, calling fn with each item in order.
+func readItemsIn(file string, fn func(Item) error, opts ...Option) error {
+	itemIDIndex, err := headerIndex(file, headerItemID, []string{headerOperation}, 1)
+	if err != nil {
+		return &Error{Kind: ErrHeaderNotFound, File: file, Header: headerItemID, Err: err}
+	}
+
+	headers := append([]string(nil), headerTextCache[file]...)
+	names := headerRule.names
+
+	var eg errgroup.Group
+
+	c := make(chan parseTarget, 2)
+	done := make(chan struct{})
+
+	eg.Go(func() error {
+		if err := readSegments(file, everyItem(), c, done, false, opts...); err != errReadCancelled {
+			return err
+		}
+
+		return nil
+	})
+	eg.Go(func() error {
+		for t := range c {
+			it := Item{ID: cellAt(t.rowContents[0], itemIDIndex), File: file, Operation: itemOperation(file, t.rowContents[0]), BeginningRow: t.beginningRow, Headers: headers, Rows: t.rowContents, names: names}
+
+			if err := fn(it); err != nil {
+				close(done)
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return eg.Wait()
+}
+
+// everyItem returns a field location matching every item.
+func everyItem() FieldLocation {
+	return FieldLocation{
+		Header: HeaderSpecification{Key: headerItemID, OthersInGroup: []string{headerOperation}, OnMatch: 1},
+		Field:  FieldSpecification{Matches: func(string) bool { return true }},
+	}
+}
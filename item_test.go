@@ -0,0 +1,93 @@
+package fusereader
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadItems(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	var items []Item
+	err := readItems([]string{path}, func(it Item) error {
+		items = append(items, it)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	if assert.Len(t, items, 3) {
+		assert.Equal(t, "00011110603081", items[0].ID)
+		assert.Equal(t, 2, items[0].BeginningRow)
+		assert.Equal(t, path, items[0].File)
+		assert.Equal(t, []string{"SOYBEANS -- Soybeans", "MILK -- Milk", "PEANUTS -- Peanuts"}, items[0].Values("Allergen Type Code"))
+		assert.Equal(t, CodeValue{Code: "FREE_FROM", Description: "Free from"}, items[0].CodeValue("Level Of Containment"))
+		assert.Equal(t, []CodeValue{{Code: "WHEAT", Description: "Wheat"}}, items[1].CodeValues("Allergen Type Code"))
+		assert.Equal(t, "", items[2].Value("Foo header"))
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = readItems([]string{path}, func(it Item) error {
+		calls++
+		return stop
+	})
+	assert.True(t, errors.Is(err, stop))
+	assert.Equal(t, 1, calls)
+}
+
+func TestReadItemsOneFileAtATime(t *testing.T) {
+	files := []string{newTestFile(t, itemTestRows()), newTestFile(t, itemTestRows()[:2])}
+
+	var seen []string
+	err := readItems(files, func(it Item) error {
+		assert.Len(t, fileCache, 1)
+		assert.Contains(t, fileCache, it.File)
+
+		seen = append(seen, it.File)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{files[0], files[0], files[0], files[1]}, seen)
+	assert.Empty(t, fileCache)
+}
+
+func TestItemValuesNormalized(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	var items []Item
+	err := readItems([]string{path}, func(it Item) error {
+		items = append(items, it)
+		return nil
+	}, NormalizeHeaders(HeaderNormalization{FoldCase: true, TrimSpace: true}))
+	assert.Nil(t, err)
+
+	if assert.NotEmpty(t, items) {
+		assert.Equal(t, "SOYBEANS -- Soybeans", items[0].Value(" allergen type code"))
+	}
+}
+
+func TestReadItemsForSlowCallback(t *testing.T) {
+	rows := itemTestRows()
+	for i := 0; i < 4; i++ {
+		rows = append(rows, rows[4])
+	}
+	path := newTestFile(t, rows)
+
+	defer func(timeout time.Duration) { parseBufferSendTimeout = timeout }(parseBufferSendTimeout)
+	parseBufferSendTimeout = 50 * time.Millisecond
+
+	calls := 0
+	err := readItems([]string{path}, func(it Item) error {
+		if calls == 0 {
+			time.Sleep(parseBufferSendTimeout * 4)
+		}
+
+		calls++
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 7, calls)
+}
@@ -0,0 +1,251 @@
+package fusereader
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ItemDiff describes how the items of one FUSE file differ from those of another.
+type ItemDiff struct {
+	Old      string       `json:"old"`      // Old is the path of the file compared against.
+	New      string       `json:"new"`      // New is the path of the compared file.
+	Added    []ItemRef    `json:"added"`    // Added contains items present only in the new file, ordered by Item ID.
+	Removed  []ItemRef    `json:"removed"`  // Removed contains items present only in the old file, ordered by Item ID.
+	Modified []ItemChange `json:"modified"` // Modified contains items present in both files with differing values, ordered by Item ID.
+}
+
+// ItemRef identifies an item within a file.
+type ItemRef struct {
+	ItemID string `json:"itemID"` // ItemID is the item's Item ID.
+	Row    int    `json:"row"`    // Row is the one-based row number of the item's first row.
+}
+
+// ItemChange describes the values of an item that differ between two files.
+type ItemChange struct {
+	ItemID string        `json:"itemID"` // ItemID is the item's Item ID, as written in the new file.
+	Values []ValueChange `json:"values"` // Values contains each differing value, in row and then column order.
+}
+
+// ValueChange describes a value that differs between two versions of an item.
+//
+// Values are aligned by header name rather than column, such that a template adding or moving columns does not by
+// itself produce changes.
+type ValueChange struct {
+	Header     string `json:"header"`     // Header is the text of the value's header.
+	Occurrence int    `json:"occurrence"` // Occurrence is the one-based occurrence of the header among headers of the same name, which for repeated groups is the group occurrence.
+	ItemRow    int    `json:"itemRow"`    // ItemRow is the zero-based index of the value's row within the item.
+	Old        string `json:"old"`        // Old is the value in the old file.
+	New        string `json:"new"`        // New is the value in the new file.
+	OldCell    string `json:"oldCell"`    // OldCell is the address of the value in the old file in A1 format, if present.
+	NewCell    string `json:"newCell"`    // NewCell is the address of the value in the new file in A1 format, if present.
+}
+
+// DiffFiles compares the items of the given files, matching items by Item ID with GTINs compared regardless of form.
+//
+// If an Item ID appears more than once within a file, only its first occurrence is compared.
+func DiffFiles(oldFile, newFile string, opts ...Option) (ItemDiff, error) {
+	items := map[string]map[string]Item{oldFile: {}, newFile: {}}
+
+	err := readItems([]string{oldFile, newFile}, func(it Item) error {
+		key := itemKey(it.ID)
+		if _, exist := items[it.File][key]; !exist {
+			items[it.File][key] = it
+		}
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return ItemDiff{}, fmt.Errorf("error while reading items: %w", err)
+	}
+
+	diff := ItemDiff{Old: oldFile, New: newFile, Added: []ItemRef{}, Removed: []ItemRef{}, Modified: []ItemChange{}}
+
+	for key, o := range items[oldFile] {
+		n, exist := items[newFile][key]
+		if !exist {
+			diff.Removed = append(diff.Removed, ItemRef{ItemID: o.ID, Row: o.BeginningRow})
+			continue
+		}
+
+		if values := diffItem(o, n); len(values) > 0 {
+			diff.Modified = append(diff.Modified, ItemChange{ItemID: n.ID, Values: values})
+		}
+	}
+
+	for key, n := range items[newFile] {
+		if _, exist := items[oldFile][key]; !exist {
+			diff.Added = append(diff.Added, ItemRef{ItemID: n.ID, Row: n.BeginningRow})
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].ItemID < diff.Added[j].ItemID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ItemID < diff.Removed[j].ItemID })
+	sort.Slice(diff.Modified, func(i, j int) bool { return diff.Modified[i].ItemID < diff.Modified[j].ItemID })
+
+	return diff, nil
+}
+
+// itemCellKey identifies a value within an item independently of its column.
+type itemCellKey struct {
+	header     string // header is the normalised text of the value's header.
+	occurrence int    // occurrence is the one-based occurrence of the header among headers of the same name.
+	row        int    // row is the zero-based index of the value's row within the item.
+}
+
+// itemCell describes a value within an item.
+type itemCell struct {
+	header string // header is the text of the value's header.
+	column int    // column is the zero-based index of the value's column.
+	value  string // value is the contents of the cell.
+}
+
+// itemCells returns the non-empty values of the given item, keyed independently of their columns.
+func itemCells(it Item) map[itemCellKey]itemCell {
+	occurrences := make([]int, len(it.Headers))
+	seen := make(map[string]int)
+
+	for i, h := range it.Headers {
+		seen[it.names.normalize(h)]++
+		occurrences[i] = seen[it.names.normalize(h)]
+	}
+
+	cells := make(map[itemCellKey]itemCell)
+
+	for r, row := range it.Rows {
+		for c, v := range row {
+			if v == "" || c >= len(it.Headers) {
+				continue
+			}
+
+			cells[itemCellKey{header: it.names.normalize(it.Headers[c]), occurrence: occurrences[c], row: r}] = itemCell{header: it.Headers[c], column: c, value: v}
+		}
+	}
+
+	return cells
+}
+
+// diffItem returns the values differing between the given versions of an item, in row and then column order.
+func diffItem(o, n Item) []ValueChange {
+	oldCells, newCells := itemCells(o), itemCells(n)
+
+	type change struct {
+		ValueChange
+		column int
+	}
+
+	var changes []change
+
+	for key, nc := range newCells {
+		oc, exist := oldCells[key]
+		if exist && oc.value == nc.value {
+			continue
+		}
+
+		vc := ValueChange{Header: nc.header, Occurrence: key.occurrence, ItemRow: key.row, New: nc.value, NewCell: cellName(nc.column, n.BeginningRow+key.row)}
+		if exist {
+			vc.Old, vc.OldCell = oc.value, cellName(oc.column, o.BeginningRow+key.row)
+		}
+
+		changes = append(changes, change{ValueChange: vc, column: nc.column})
+	}
+
+	for key, oc := range oldCells {
+		if _, exist := newCells[key]; exist {
+			continue
+		}
+
+		vc := ValueChange{Header: oc.header, Occurrence: key.occurrence, ItemRow: key.row, Old: oc.value, OldCell: cellName(oc.column, o.BeginningRow+key.row)}
+		changes = append(changes, change{ValueChange: vc, column: oc.column})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].ItemRow != changes[j].ItemRow {
+			return changes[i].ItemRow < changes[j].ItemRow
+		}
+
+		return changes[i].column < changes[j].column
+	})
+
+	out := make([]ValueChange, len(changes))
+	for i, c := range changes {
+		out[i] = c.ValueChange
+	}
+
+	return out
+}
+
+// cellName returns the A1 address of the cell at the given zero-based column and one-based row.
+func cellName(column, row int) string {
+	a, _ := excelize.CoordinatesToCellName(column+1, row)
+	return a
+}
+
+// Changed returns true if the files' items differ.
+func (d ItemDiff) Changed() bool {
+	return len(d.Added)+len(d.Removed)+len(d.Modified) > 0
+}
+
+// JSON returns the diff encoded as indented JSON.
+func (d ItemDiff) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// WriteCSV writes the diff to the given writer as CSV, with a record per added or removed item and per modified value.
+func (d ItemDiff) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	records := [][]string{{"Change", "Item ID", "Header", "Occurrence", "Item Row", "Old Cell", "Old Value", "New Cell", "New Value"}}
+
+	for _, a := range d.Added {
+		records = append(records, []string{"added", a.ItemID, "", "", "", "", "", cellName(0, a.Row), ""})
+	}
+
+	for _, r := range d.Removed {
+		records = append(records, []string{"removed", r.ItemID, "", "", "", cellName(0, r.Row), "", "", ""})
+	}
+
+	for _, c := range d.Modified {
+		for _, v := range c.Values {
+			records = append(records, []string{"modified", c.ItemID, v.Header, strconv.Itoa(v.Occurrence), strconv.Itoa(v.ItemRow), v.OldCell, v.Old, v.NewCell, v.New})
+		}
+	}
+
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("error while writing CSV: %w", err)
+	}
+
+	return nil
+}
+
+// String returns a human-readable report of the diff.
+func (d ItemDiff) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s -> %s: %d added, %d removed, %d modified\n", filepath.Base(d.Old), filepath.Base(d.New), len(d.Added), len(d.Removed), len(d.Modified))
+
+	for _, a := range d.Added {
+		fmt.Fprintf(&b, "  + %s (row %d)\n", a.ItemID, a.Row)
+	}
+
+	for _, r := range d.Removed {
+		fmt.Fprintf(&b, "  - %s (row %d)\n", r.ItemID, r.Row)
+	}
+
+	for _, c := range d.Modified {
+		fmt.Fprintf(&b, "  ~ %s\n", c.ItemID)
+
+		for _, v := range c.Values {
+			fmt.Fprintf(&b, "      %s #%d: %q (%s) -> %q (%s)\n", v.Header, v.Occurrence, v.Old, v.OldCell, v.New, v.NewCell)
+		}
+	}
+
+	return b.String()
+}
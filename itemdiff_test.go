@@ -0,0 +1,52 @@
+package fusereader
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFiles(t *testing.T) {
+	old := newTestFile(t, itemTestRows())
+
+	rows := itemTestRows()
+	rows[1][12] = "MAY_CONTAIN -- May contain" // Second allergen group of the first item.
+	rows[2] = []string{""}                     // Blanks the continuation row of the first item.
+	rows = append(rows, []string{itemRecordType, "ADD", "Y", "0614141000012", "Acme", "GTIN", "00011110603098"})
+	changed := newTestFile(t, append(rows[:3], rows[4:]...)) // Drops the second item.
+
+	got, err := DiffFiles(old, changed)
+	require.Nil(t, err)
+	assert.True(t, got.Changed())
+
+	assert.Equal(t, []ItemRef{{ItemID: "00011110603098", Row: 5}}, got.Added)
+	assert.Equal(t, []ItemRef{{ItemID: "10011110603088", Row: 4}}, got.Removed)
+
+	if assert.Len(t, got.Modified, 1) {
+		assert.Equal(t, "00011110603081", got.Modified[0].ItemID)
+		assert.Equal(t, []ValueChange{
+			{Header: "Level Of Containment", Occurrence: 2, ItemRow: 0, Old: "CONTAINS -- Contains", New: "MAY_CONTAIN -- May contain", OldCell: "M2", NewCell: "M2"},
+			{Header: "Allergen Type Code", Occurrence: 1, ItemRow: 1, Old: "PEANUTS -- Peanuts", OldCell: "I3"},
+			{Header: "Level Of Containment", Occurrence: 1, ItemRow: 1, Old: "MAY_CONTAIN -- May contain", OldCell: "J3"},
+		}, got.Modified[0].Values)
+	}
+
+	var buf bytes.Buffer
+	require.Nil(t, got.WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.Nil(t, err)
+	assert.Len(t, records, 6)
+	assert.Equal(t, []string{"modified", "00011110603081", "Level Of Containment", "2", "0", "M2", "CONTAINS -- Contains", "M2", "MAY_CONTAIN -- May contain"}, records[3])
+
+	same, err := DiffFiles(old, newTestFile(t, itemTestRows()))
+	require.Nil(t, err)
+	assert.False(t, same.Changed())
+
+	b, err := same.JSON()
+	require.Nil(t, err)
+	assert.NotContains(t, string(b), "null")
+}
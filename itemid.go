@@ -0,0 +1,53 @@
+package fusereader
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kindred87/fusereader/gtin"
+	"github.com/xuri/excelize/v2"
+)
+
+// MatchGTIN returns a function, suitable for FieldSpecification.Matches, that returns true if a value is the same
+// GTIN as any of the given GTINs, regardless of whether either is written in 8-, 12-, 13- or 14-digit form.
+func MatchGTIN(gtins ...string) func(string) bool {
+	set := make(map[string]bool, len(gtins))
+	for _, g := range gtins {
+		if n, err := gtin.Normalize(g); err == nil {
+			set[n] = true
+		}
+	}
+
+	return func(s string) bool {
+		n, err := gtin.Normalize(s)
+		return err == nil && set[n]
+	}
+}
+
+// NewGTINFieldLocation returns a field location identifying items whose Item ID is any of the given GTINs, in any
+// form.
+func NewGTINFieldLocation(id string, gtins ...string) FieldLocation {
+	return FieldLocation{
+		ID:     id,
+		Header: HeaderSpecification{Key: headerItemID, OthersInGroup: []string{headerOperation}, OnMatch: 1},
+		Field:  FieldSpecification{Matches: MatchGTIN(gtins...)},
+	}
+}
+
+// warnInvalidGTIN sends a warning if the item ID within the given first row of an item is a GTIN with an invalid
+// format or check digit.  Item IDs of a type other than GTIN are not checked.
+func warnInvalidGTIN(warnings optionReportWarnings, file string, row int, itemIDIndex, itemTypeIndex int, cells []string) {
+	id := cellAt(cells, itemIDIndex)
+	if id == "" {
+		return
+	}
+
+	if itemType := strings.TrimSpace(cellAt(cells, itemTypeIndex)); itemType != "" && !strings.EqualFold(itemType, "GTIN") {
+		return
+	}
+
+	if _, err := gtin.Validate(id); err != nil {
+		a, _ := excelize.CoordinatesToCellName(itemIDIndex+1, row)
+		warnings.warn(Warning{Kind: WarningInvalidGTIN, File: file, Row: row, Cell: a, Message: fmt.Sprintf("item ID %s: %v", id, err)})
+	}
+}
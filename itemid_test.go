@@ -0,0 +1,51 @@
+package fusereader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGTIN(t *testing.T) {
+	matches := MatchGTIN("011110603081", "not a GTIN")
+
+	assert.True(t, matches("00011110603081"))
+	assert.True(t, matches("0011110603081"))
+	assert.False(t, matches("10011110603088"))
+	assert.False(t, matches("not a GTIN"))
+}
+
+func TestGetFieldsForGTINFieldLocation(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{NewGTINFieldLocation("UPC", "077661003169")}, []FieldRetrieval{validRetrieveSpec()}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "00077661003169", got[0].ItemID())
+	}
+}
+
+func TestGetFieldsForFlagInvalidGTINs(t *testing.T) {
+	rows := itemTestRows()
+	rows[3][6] = "10011110603089"
+	rows = append(rows, []string{itemRecordType, "ADD", "Y", "", "", "SKU", "ABC-123"})
+	path := newTestFile(t, rows)
+
+	var got []Warning
+	report := ReportWarnings(func(w Warning) { got = append(got, w) })
+
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, make(chan field, 10), report)
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+
+	err = GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, make(chan field, 10), report, FlagInvalidGTINs())
+	assert.Nil(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, WarningInvalidGTIN, got[0].Kind)
+		assert.Equal(t, "G4", got[0].Cell)
+	}
+}
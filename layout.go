@@ -0,0 +1,135 @@
+package fusereader
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Layout describes the header groups within a FUSE file.
+type Layout struct {
+	File      string        `json:"file"`      // File is the path of the described file.
+	HeaderRow int           `json:"headerRow"` // HeaderRow is the one-based number of the header row.
+	Groups    []HeaderGroup `json:"groups"`    // Groups contains the header groups, in column order.
+}
+
+// HeaderGroup describes a group of adjacent headers.
+type HeaderGroup struct {
+	Start       int            `json:"start"`       // Start is the zero-based index of the first column in the group.
+	StartColumn string         `json:"startColumn"` // StartColumn is the letter of the first column in the group.
+	Headers     []LayoutHeader `json:"headers"`     // Headers contains the headers in the group, in column order.
+	Occurrence  int            `json:"occurrence"`  // Occurrence is the one-based number of groups up to and including this one that share its shape.
+	Repeats     int            `json:"repeats"`     // Repeats is the total number of groups in the file sharing this group's shape.
+}
+
+// LayoutHeader describes a single header.
+type LayoutHeader struct {
+	Index  int    `json:"index"`  // Index is the zero-based index of the header's column.
+	Column string `json:"column"` // Column is the letter of the header's column.
+	Name   string `json:"name"`   // Name is the text of the header.
+}
+
+// shape returns a key identifying the ordered header names of the group.
+func (g HeaderGroup) shape() string {
+	names := make([]string, len(g.Headers))
+	for i, h := range g.Headers {
+		names[i] = h.Name
+	}
+
+	return strings.Join(names, "\x00")
+}
+
+// JSON returns the layout encoded as indented JSON.
+func (l Layout) JSON() ([]byte, error) {
+	return json.MarshalIndent(l, "", "  ")
+}
+
+// GetLayout returns the header group layout of the given file.
+//
+// Header row options determine how the header row is identified.  As GetLayout uses the same header caches as
+// GetFields, calls are serialised with other runs.
+func GetLayout(path string, opts ...Option) (Layout, error) {
+	runMu.Lock()
+	defer runMu.Unlock()
+
+	fi, err := excelize.OpenFile(path)
+	if err != nil {
+		return Layout{}, &Error{Kind: ErrFileOpen, File: path, Err: err}
+	}
+	defer fi.Close()
+
+	headerRule = headerRowRuleFrom(opts...)
+
+	if err := buildHeaderCaches(fi); err != nil {
+		return Layout{}, fmt.Errorf("error while building header caches: %w", err)
+	}
+	defer removeHeaderCaches()
+
+	headers, err := headersFrom(fi)
+	if err != nil {
+		return Layout{}, fmt.Errorf("error while getting headers from %s: %w", filepath.Base(path), err)
+	}
+
+	groups, err := headerGroups(sharedHeaderCacheKey, headers)
+	if err != nil {
+		return Layout{}, fmt.Errorf("error while grouping headers in %s: %w", filepath.Base(path), err)
+	}
+
+	return Layout{File: path, HeaderRow: headerRowCache[path], Groups: groups}, nil
+}
+
+// headerGroups returns the given headers partitioned using the group roots cached under the given key.
+func headerGroups(cacheKey string, headers []string) ([]HeaderGroup, error) {
+	tree, exist := headerGroupRootCache[cacheKey]
+	if !exist {
+		return nil, fmt.Errorf("the key %s does not exist in the header group root cache", cacheKey)
+	}
+
+	var roots []int
+	for _, k := range tree.Keys() {
+		roots = append(roots, k.(int))
+	}
+
+	var groups []HeaderGroup
+	shapeCounts := make(map[string]int)
+
+	for i, root := range roots {
+		start, end := root, len(headers)
+		if start < 0 {
+			start = 0
+		}
+		if i+1 < len(roots) {
+			end = roots[i+1]
+		}
+		if start >= end {
+			continue
+		}
+
+		g := HeaderGroup{Start: start}
+
+		for j := start; j < end; j++ {
+			col, err := excelize.ColumnNumberToName(j + 1)
+			if err != nil {
+				return nil, fmt.Errorf("error while converting column %d to a name: %w", j+1, err)
+			}
+
+			g.Headers = append(g.Headers, LayoutHeader{Index: j, Column: col, Name: headers[j]})
+		}
+
+		g.StartColumn = g.Headers[0].Column
+
+		shapeCounts[g.shape()]++
+		g.Occurrence = shapeCounts[g.shape()]
+
+		groups = append(groups, g)
+	}
+
+	for i := range groups {
+		groups[i].Repeats = shapeCounts[groups[i].shape()]
+	}
+
+	return groups, nil
+}
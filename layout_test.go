@@ -0,0 +1,53 @@
+package fusereader
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// groupedHeaderRow returns a header row containing the FUSE prefix followed by the given number of allergen groups.
+func groupedHeaderRow(allergenGroups int) []string {
+	row := headerRowPrefix()
+
+	for i := 0; i < allergenGroups; i++ {
+		row = append(row, headerNewGroupIndicator, "Allergen Type Code", "Level Of Containment")
+	}
+
+	return row
+}
+
+func TestGetLayout(t *testing.T) {
+	path := newTestFile(t, [][]string{{"Banner"}, groupedHeaderRow(2)})
+
+	got, err := GetLayout(path)
+	require.Nil(t, err)
+
+	assert.Equal(t, 2, got.HeaderRow)
+	require.Len(t, got.Groups, 3)
+
+	assert.Equal(t, 0, got.Groups[0].Start)
+	assert.Equal(t, "A", got.Groups[0].StartColumn)
+	assert.Len(t, got.Groups[0].Headers, len(headerRowPrefix()))
+	assert.Equal(t, 1, got.Groups[0].Repeats)
+
+	assert.Equal(t, 7, got.Groups[1].Start)
+	assert.Equal(t, "H", got.Groups[1].StartColumn)
+	assert.Equal(t, LayoutHeader{Index: 8, Column: "I", Name: "Allergen Type Code"}, got.Groups[1].Headers[1])
+	assert.Equal(t, 1, got.Groups[1].Occurrence)
+	assert.Equal(t, 2, got.Groups[1].Repeats)
+
+	assert.Equal(t, "K", got.Groups[2].StartColumn)
+	assert.Equal(t, 2, got.Groups[2].Occurrence)
+
+	b, err := got.JSON()
+	require.Nil(t, err)
+
+	var decoded Layout
+	require.Nil(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, got, decoded)
+
+	assert.Nil(t, headerCache)
+}
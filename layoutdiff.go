@@ -0,0 +1,238 @@
+package fusereader
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LayoutDiff describes how the header layouts of one or more files differ from a baseline file.
+type LayoutDiff struct {
+	Baseline string           `json:"baseline"` // Baseline is the path of the file compared against.
+	Files    []FileLayoutDiff `json:"files"`    // Files contains the differences for each compared file.
+}
+
+// FileLayoutDiff describes how the header layout of a single file differs from the baseline.
+type FileLayoutDiff struct {
+	File           string         `json:"file"`           // File is the path of the compared file.
+	HeadersAdded   []LayoutHeader `json:"headersAdded"`   // HeadersAdded contains headers absent from the baseline.
+	HeadersRemoved []LayoutHeader `json:"headersRemoved"` // HeadersRemoved contains baseline headers absent from the file.
+	HeadersMoved   []HeaderMove   `json:"headersMoved"`   // HeadersMoved contains headers present in both, but in different columns.
+	GroupsAdded    []HeaderGroup  `json:"groupsAdded"`    // GroupsAdded contains groups absent from the baseline.
+	GroupsRemoved  []HeaderGroup  `json:"groupsRemoved"`  // GroupsRemoved contains baseline groups absent from the file.
+	GroupsMoved    []GroupMove    `json:"groupsMoved"`    // GroupsMoved contains groups present in both, but starting in different columns.
+}
+
+// HeaderMove describes a header that appears in different columns of two files.
+type HeaderMove struct {
+	Name       string `json:"name"`       // Name is the text of the header.
+	Occurrence int    `json:"occurrence"` // Occurrence is the one-based occurrence of the header among headers of the same name.
+	From       string `json:"from"`       // From is the letter of the header's column in the baseline.
+	To         string `json:"to"`         // To is the letter of the header's column in the compared file.
+}
+
+// GroupMove describes a header group that starts in different columns of two files.
+type GroupMove struct {
+	Headers    []string `json:"headers"`    // Headers contains the names of the headers in the group.
+	Occurrence int      `json:"occurrence"` // Occurrence is the one-based occurrence of the group among groups of the same shape.
+	From       string   `json:"from"`       // From is the letter of the group's first column in the baseline.
+	To         string   `json:"to"`         // To is the letter of the group's first column in the compared file.
+}
+
+// Changed returns true if the file's layout differs from the baseline.
+func (d FileLayoutDiff) Changed() bool {
+	return len(d.HeadersAdded)+len(d.HeadersRemoved)+len(d.HeadersMoved)+len(d.GroupsAdded)+len(d.GroupsRemoved)+len(d.GroupsMoved) > 0
+}
+
+// Changed returns true if any compared file's layout differs from the baseline.
+func (d LayoutDiff) Changed() bool {
+	for _, f := range d.Files {
+		if f.Changed() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// JSON returns the diff encoded as indented JSON.
+func (d LayoutDiff) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// String returns a human-readable report of the diff.
+func (d LayoutDiff) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "baseline: %s\n", filepath.Base(d.Baseline))
+
+	for _, f := range d.Files {
+		if !f.Changed() {
+			fmt.Fprintf(&b, "%s: no changes\n", filepath.Base(f.File))
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s:\n", filepath.Base(f.File))
+
+		for _, h := range f.HeadersAdded {
+			fmt.Fprintf(&b, "  + header %q at %s\n", h.Name, h.Column)
+		}
+		for _, h := range f.HeadersRemoved {
+			fmt.Fprintf(&b, "  - header %q at %s\n", h.Name, h.Column)
+		}
+		for _, m := range f.HeadersMoved {
+			fmt.Fprintf(&b, "  ~ header %q (occurrence %d) moved from %s to %s\n", m.Name, m.Occurrence, m.From, m.To)
+		}
+		for _, g := range f.GroupsAdded {
+			fmt.Fprintf(&b, "  + group %s at %s\n", groupSummary(g.Headers), g.StartColumn)
+		}
+		for _, g := range f.GroupsRemoved {
+			fmt.Fprintf(&b, "  - group %s at %s\n", groupSummary(g.Headers), g.StartColumn)
+		}
+		for _, m := range f.GroupsMoved {
+			fmt.Fprintf(&b, "  ~ group %q (occurrence %d) moved from %s to %s\n", m.Headers, m.Occurrence, m.From, m.To)
+		}
+	}
+
+	return b.String()
+}
+
+// groupSummary returns the quoted names of the given headers.
+func groupSummary(headers []LayoutHeader) string {
+	names := make([]string, len(headers))
+	for i, h := range headers {
+		names[i] = h.Name
+	}
+
+	return fmt.Sprintf("%q", names)
+}
+
+// DiffLayouts compares the header layouts of the given files against the first given file.
+//
+// Header row options determine how the header row of each file is identified.
+func DiffLayouts(files []string, opts ...Option) (LayoutDiff, error) {
+	if len(files) == 0 {
+		return LayoutDiff{}, fmt.Errorf("no files were given")
+	}
+
+	baseline, err := GetLayout(files[0], opts...)
+	if err != nil {
+		return LayoutDiff{}, fmt.Errorf("error while getting layout of baseline %s: %w", filepath.Base(files[0]), err)
+	}
+
+	out := LayoutDiff{Baseline: files[0], Files: []FileLayoutDiff{}}
+
+	for _, file := range files[1:] {
+		l, err := GetLayout(file, opts...)
+		if err != nil {
+			return LayoutDiff{}, fmt.Errorf("error while getting layout of %s: %w", filepath.Base(file), err)
+		}
+
+		out.Files = append(out.Files, diffLayout(baseline, l))
+	}
+
+	return out, nil
+}
+
+// diffLayout returns the differences between the given layouts.
+func diffLayout(baseline, compared Layout) FileLayoutDiff {
+	out := FileLayoutDiff{
+		File:           compared.File,
+		HeadersAdded:   []LayoutHeader{},
+		HeadersRemoved: []LayoutHeader{},
+		HeadersMoved:   []HeaderMove{},
+		GroupsAdded:    []HeaderGroup{},
+		GroupsRemoved:  []HeaderGroup{},
+		GroupsMoved:    []GroupMove{},
+	}
+
+	before, after := layoutHeadersByOccurrence(baseline), layoutHeadersByOccurrence(compared)
+
+	for k, h := range before {
+		moved, exist := after[k]
+		if !exist {
+			out.HeadersRemoved = append(out.HeadersRemoved, h)
+		} else if moved.Index != h.Index {
+			out.HeadersMoved = append(out.HeadersMoved, HeaderMove{Name: h.Name, Occurrence: k.occurrence, From: h.Column, To: moved.Column})
+		}
+	}
+
+	for k, h := range after {
+		if _, exist := before[k]; !exist {
+			out.HeadersAdded = append(out.HeadersAdded, h)
+		}
+	}
+
+	sort.Slice(out.HeadersRemoved, func(i, j int) bool { return out.HeadersRemoved[i].Index < out.HeadersRemoved[j].Index })
+	sort.Slice(out.HeadersAdded, func(i, j int) bool { return out.HeadersAdded[i].Index < out.HeadersAdded[j].Index })
+	sort.Slice(out.HeadersMoved, func(i, j int) bool {
+		return before[occurrenceKey{out.HeadersMoved[i].Name, out.HeadersMoved[i].Occurrence}].Index < before[occurrenceKey{out.HeadersMoved[j].Name, out.HeadersMoved[j].Occurrence}].Index
+	})
+
+	beforeGroups, afterGroups := layoutGroupsByOccurrence(baseline), layoutGroupsByOccurrence(compared)
+
+	for _, g := range baseline.Groups {
+		moved, exist := afterGroups[occurrenceKey{name: g.shape(), occurrence: g.Occurrence}]
+		if !exist {
+			out.GroupsRemoved = append(out.GroupsRemoved, g)
+		} else if moved.Start != g.Start {
+			names := make([]string, 0, len(g.Headers))
+			for _, h := range g.Headers {
+				names = append(names, h.Name)
+			}
+
+			out.GroupsMoved = append(out.GroupsMoved, GroupMove{Headers: names, Occurrence: g.Occurrence, From: g.StartColumn, To: moved.StartColumn})
+		}
+	}
+
+	for _, g := range compared.Groups {
+		if _, exist := beforeGroups[occurrenceKey{name: g.shape(), occurrence: g.Occurrence}]; !exist {
+			out.GroupsAdded = append(out.GroupsAdded, g)
+		}
+	}
+
+	return out
+}
+
+// occurrenceKey identifies a header or group by its name and its one-based occurrence among others of the same name.
+type occurrenceKey struct {
+	name       string
+	occurrence int
+}
+
+// layoutHeaders returns every header in the given layout, in column order.
+func layoutHeaders(l Layout) []LayoutHeader {
+	var out []LayoutHeader
+
+	for _, g := range l.Groups {
+		out = append(out, g.Headers...)
+	}
+
+	return out
+}
+
+// layoutHeadersByOccurrence returns the headers in the given layout keyed by name and occurrence.
+func layoutHeadersByOccurrence(l Layout) map[occurrenceKey]LayoutHeader {
+	out := make(map[occurrenceKey]LayoutHeader)
+	counts := make(map[string]int)
+
+	for _, h := range layoutHeaders(l) {
+		counts[h.Name]++
+		out[occurrenceKey{name: h.Name, occurrence: counts[h.Name]}] = h
+	}
+
+	return out
+}
+
+// layoutGroupsByOccurrence returns the groups in the given layout keyed by shape and occurrence.
+func layoutGroupsByOccurrence(l Layout) map[occurrenceKey]HeaderGroup {
+	out := make(map[occurrenceKey]HeaderGroup)
+
+	for _, g := range l.Groups {
+		out[occurrenceKey{name: g.shape(), occurrence: g.Occurrence}] = g
+	}
+
+	return out
+}
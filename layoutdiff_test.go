@@ -0,0 +1,50 @@
+package fusereader
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffLayouts(t *testing.T) {
+	baseline := newTestFile(t, [][]string{groupedHeaderRow(2)})
+	same := newTestFile(t, [][]string{groupedHeaderRow(2)})
+	fewer := newTestFile(t, [][]string{groupedHeaderRow(1)})
+	inserted := newTestFile(t, [][]string{append(append(headerRowPrefix(), "Brand Name"), groupedHeaderRow(2)[len(headerRowPrefix()):]...)})
+
+	got, err := DiffLayouts([]string{baseline, same, fewer, inserted})
+	require.Nil(t, err)
+	require.Len(t, got.Files, 3)
+	assert.True(t, got.Changed())
+
+	assert.False(t, got.Files[0].Changed())
+
+	assert.Len(t, got.Files[1].HeadersRemoved, 3)
+	assert.Empty(t, got.Files[1].HeadersAdded)
+	assert.Empty(t, got.Files[1].HeadersMoved)
+	require.Len(t, got.Files[1].GroupsRemoved, 1)
+	assert.Equal(t, "K", got.Files[1].GroupsRemoved[0].StartColumn)
+
+	assert.Equal(t, []LayoutHeader{{Index: 7, Column: "H", Name: "Brand Name"}}, got.Files[2].HeadersAdded)
+	assert.Len(t, got.Files[2].HeadersMoved, 6)
+	assert.Equal(t, HeaderMove{Name: headerNewGroupIndicator, Occurrence: 1, From: "H", To: "I"}, got.Files[2].HeadersMoved[0])
+	assert.Len(t, got.Files[2].GroupsAdded, 1)
+	assert.Len(t, got.Files[2].GroupsRemoved, 1)
+	assert.Len(t, got.Files[2].GroupsMoved, 2)
+
+	assert.Contains(t, got.String(), `+ header "Brand Name" at H`)
+
+	b, err := got.JSON()
+	require.Nil(t, err)
+	assert.True(t, json.Valid(b))
+	assert.Contains(t, string(b), `"headersAdded": []`)
+	assert.NotContains(t, string(b), "null")
+
+	got, err = DiffLayouts([]string{baseline})
+	require.Nil(t, err)
+	b, err = got.JSON()
+	require.Nil(t, err)
+	assert.Contains(t, string(b), `"files": []`)
+}
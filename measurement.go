@@ -0,0 +1,117 @@
+package fusereader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// dimension describes the physical quantity a unit of measure measures.
+type dimension int
+
+const (
+	dimensionLength dimension = iota
+	dimensionMass
+	dimensionVolume
+)
+
+// unitOfMeasure describes a GS1 unit of measure relative to the base unit of its dimension.
+type unitOfMeasure struct {
+	dimension dimension // dimension is the physical quantity measured by the unit.
+	factor    float64   // factor is the number of base units in one of the unit.
+}
+
+// unitsOfMeasure contains the GS1 units of measure that measurements may be converted between, keyed by GS1 code.
+// Base units are centimetres, grams and millilitres.
+var unitsOfMeasure = map[string]unitOfMeasure{
+	"MMT": {dimension: dimensionLength, factor: 0.1},
+	"CMT": {dimension: dimensionLength, factor: 1},
+	"MTR": {dimension: dimensionLength, factor: 100},
+	"INH": {dimension: dimensionLength, factor: 2.54},
+	"FOT": {dimension: dimensionLength, factor: 30.48},
+	"MGM": {dimension: dimensionMass, factor: 0.001},
+	"GRM": {dimension: dimensionMass, factor: 1},
+	"KGM": {dimension: dimensionMass, factor: 1000},
+	"ONZ": {dimension: dimensionMass, factor: 28.349523125},
+	"LBR": {dimension: dimensionMass, factor: 453.59237},
+	"MLT": {dimension: dimensionVolume, factor: 1},
+	"LTR": {dimension: dimensionVolume, factor: 1000},
+	"OZA": {dimension: dimensionVolume, factor: 29.5735295625},
+	"GLL": {dimension: dimensionVolume, factor: 3785.411784},
+}
+
+// normalUnits contains the unit each dimension is normalised to.
+var normalUnits = map[dimension]string{
+	dimensionLength: "CMT",
+	dimensionMass:   "GRM",
+	dimensionVolume: "MLT",
+}
+
+// Measurement is a quantity paired with its GS1 unit of measure, such as 12 ONZ.
+type Measurement struct {
+	Value float64 // Value is the quantity.
+	Unit  string  // Unit is the GS1 unit of measure code, such as CMT or ONZ.
+}
+
+// ParseMeasurement returns the measurement described by the given value and unit of measure.
+//
+// Units may be given as FUSE code-list values, such as "ONZ -- Ounce", of which only the code is kept.
+func ParseMeasurement(value, unit string) (Measurement, error) {
+	m, err := parseMeasurement(value, unit)
+	if err != nil {
+		return Measurement{}, fmt.Errorf("%w: %v", ErrValueParse, err)
+	}
+
+	return m, nil
+}
+
+// parseMeasurement performs the work of ParseMeasurement, returning errors that describe the failure without its kind.
+func parseMeasurement(value, unit string) (Measurement, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return Measurement{}, fmt.Errorf("%q is not a quantity", value)
+	}
+
+	u := strings.ToUpper(ParseCodeValue(unit).Code)
+	if u == "" {
+		return Measurement{}, fmt.Errorf("measurement of %s has no unit", value)
+	}
+
+	return Measurement{Value: v, Unit: u}, nil
+}
+
+// Convert returns the measurement in the given GS1 unit of measure.
+//
+// An error is returned if either unit is unknown or the units measure different quantities, such as mass and volume.
+func (m Measurement) Convert(unit string) (Measurement, error) {
+	from, exist := unitsOfMeasure[m.Unit]
+	if !exist {
+		return Measurement{}, fmt.Errorf("%w: unknown unit %s", ErrUnitConversion, m.Unit)
+	}
+
+	to, exist := unitsOfMeasure[unit]
+	if !exist {
+		return Measurement{}, fmt.Errorf("%w: unknown unit %s", ErrUnitConversion, unit)
+	}
+
+	if from.dimension != to.dimension {
+		return Measurement{}, fmt.Errorf("%w: %s and %s measure different quantities", ErrUnitConversion, m.Unit, unit)
+	}
+
+	return Measurement{Value: m.Value * from.factor / to.factor, Unit: unit}, nil
+}
+
+// Normalize returns the measurement in centimetres, grams or millilitres, according to the quantity it measures.
+func (m Measurement) Normalize() (Measurement, error) {
+	u, exist := unitsOfMeasure[m.Unit]
+	if !exist {
+		return Measurement{}, fmt.Errorf("%w: unknown unit %s", ErrUnitConversion, m.Unit)
+	}
+
+	return m.Convert(normalUnits[u.dimension])
+}
+
+// String returns the measurement as its quantity followed by its unit, such as "12 ONZ".
+func (m Measurement) String() string {
+	return strconv.FormatFloat(m.Value, 'f', -1, 64) + " " + m.Unit
+}
@@ -0,0 +1,124 @@
+package fusereader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMeasurement(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		unit    string
+		want    Measurement
+		wantErr bool
+	}{
+		{name: "Code", value: "12", unit: "ONZ", want: Measurement{Value: 12, Unit: "ONZ"}},
+		{name: "Code-list value", value: " 2.5 ", unit: "lbr -- Pound", want: Measurement{Value: 2.5, Unit: "LBR"}},
+		{name: "Bad quantity", value: "twelve", unit: "ONZ", wantErr: true},
+		{name: "No unit", value: "12", unit: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMeasurement(tt.value, tt.unit)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrValueParse), "got %v", err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMeasurementConvert(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Measurement
+		unit    string
+		want    float64
+		wantErr bool
+	}{
+		{name: "Inches to centimetres", m: Measurement{Value: 10, Unit: "INH"}, unit: "CMT", want: 25.4},
+		{name: "Ounces to grams", m: Measurement{Value: 16, Unit: "ONZ"}, unit: "GRM", want: 453.59237},
+		{name: "Pounds to kilograms", m: Measurement{Value: 1, Unit: "LBR"}, unit: "KGM", want: 0.45359237},
+		{name: "Fluid ounces to millilitres", m: Measurement{Value: 12, Unit: "OZA"}, unit: "MLT", want: 354.882354750},
+		{name: "Centimetres to inches", m: Measurement{Value: 2.54, Unit: "CMT"}, unit: "INH", want: 1},
+		{name: "Mass to volume", m: Measurement{Value: 1, Unit: "ONZ"}, unit: "MLT", wantErr: true},
+		{name: "Unknown unit", m: Measurement{Value: 1, Unit: "XYZ"}, unit: "MLT", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Convert(tt.unit)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrUnitConversion), "got %v", err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.unit, got.Unit)
+			assert.InDelta(t, tt.want, got.Value, 1e-9)
+		})
+	}
+
+	got, err := Measurement{Value: 2, Unit: "LTR"}.Normalize()
+	assert.Nil(t, err)
+	assert.Equal(t, Measurement{Value: 2000, Unit: "MLT"}, got)
+	assert.Equal(t, "2000 MLT", got.String())
+}
+
+func TestGetFieldsForMeasurement(t *testing.T) {
+	header := append(headerRowPrefix(), headerNewGroupIndicator, "Net Content", "Net Content UOM", headerNewGroupIndicator, "Net Content", "Net Content UOM")
+	path := newTestFile(t, [][]string{
+		header,
+		{itemRecordType, "ADD", "Y", "", "", "GTIN", "00011110603081", "", "12", "OZA -- Fluid ounce", "", "1.5", "LBR"},
+	})
+
+	retrieve := FieldRetrieval{
+		ID:           "Net content",
+		Header:       HeaderSpecification{Key: "Net Content", FanOut: true},
+		Field:        FieldSpecification{Matches: func(s string) bool { return s != "" }},
+		FieldOffsets: []int{0},
+		UnitHeader:   "Net Content UOM",
+	}
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	if assert.Len(t, got, 2) {
+		m, err := got[0].Measurement()
+		assert.Nil(t, err)
+		assert.Equal(t, Measurement{Value: 12, Unit: "OZA"}, m)
+
+		m, err = got[1].Measurement()
+		assert.Nil(t, err)
+
+		m, err = m.Normalize()
+		assert.Nil(t, err)
+		assert.InDelta(t, 680.388555, m.Value, 1e-6)
+	}
+
+	retrieve.UnitHeader = "Gross Weight UOM"
+	err = GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{retrieve}, make(chan field, 10))
+	assert.True(t, errors.Is(err, ErrHeaderNotFound), "got %v", err)
+}
+
+func Test_fieldMeasurementError(t *testing.T) {
+	f := field{file: "fuse.xlsx", specID: "Retrieve spec 01", header: "Net Content", value: "twelve", unit: "OZA", address: "M4"}
+
+	_, err := f.Measurement()
+	assert.True(t, errors.Is(err, ErrValueParse))
+	assert.Equal(t, `value could not be parsed (file fuse.xlsx, spec "Retrieve spec 01", header "Net Content", cell M4): "twelve" is not a quantity`, err.Error())
+
+	var e *Error
+	if assert.True(t, errors.As(err, &e)) {
+		assert.Equal(t, "Retrieve spec 01", e.SpecID)
+		assert.Equal(t, "M4", e.Cell)
+	}
+}
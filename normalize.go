@@ -0,0 +1,94 @@
+package fusereader
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// whitespaceRun matches one or more consecutive whitespace characters.
+var whitespaceRun = regexp.MustCompile(`\s+`)
+
+// HeaderNormalization describes how header text is normalised before headers are compared.
+type HeaderNormalization struct {
+	TrimSpace     bool // TrimSpace removes leading and trailing whitespace.
+	FoldCase      bool // FoldCase makes comparisons insensitive to case.
+	CollapseSpace bool // CollapseSpace replaces each run of whitespace with a single space.
+}
+
+// apply returns the given header normalised according to the policy.
+func (n HeaderNormalization) apply(header string) string {
+	if n.CollapseSpace {
+		header = whitespaceRun.ReplaceAllString(header, " ")
+	}
+
+	if n.TrimSpace {
+		header = strings.TrimSpace(header)
+	}
+
+	if n.FoldCase {
+		header = strings.ToLower(header)
+	}
+
+	return header
+}
+
+// pattern returns the given pattern adjusted to match normalised header text, or nil if the given pattern is nil.
+// When case is folded, the pattern is made insensitive to case.
+func (n headerNormalizer) pattern(p *regexp.Regexp) *regexp.Regexp {
+	if p == nil || !n.policy.FoldCase {
+		return p
+	} else if n.folded == nil {
+		return regexp.MustCompile("(?i)" + p.String())
+	}
+
+	if f, exist := n.folded.Load(p); exist {
+		return f.(*regexp.Regexp)
+	}
+
+	f := regexp.MustCompile("(?i)" + p.String())
+	n.folded.Store(p, f)
+
+	return f
+}
+
+// headerNormalizer normalises header text using a normalisation policy and an alias table.
+type headerNormalizer struct {
+	policy  HeaderNormalization // policy is applied to all header text.
+	aliases map[string]string   // aliases maps normalised alternate names to normalised canonical names.
+	folded  *sync.Map           // folded caches the case-insensitive form of each pattern given to pattern, if case is folded.
+}
+
+// headerNormalizerFrom returns the header normalizer described by the given options.
+func headerNormalizerFrom(opts ...Option) headerNormalizer {
+	var out headerNormalizer
+
+	if o, ok := normalizeHeadersFrom(opts...); ok {
+		out.policy = o.policy
+	}
+
+	if out.policy.FoldCase {
+		out.folded = &sync.Map{}
+	}
+
+	if o, ok := headerAliasesFrom(opts...); ok {
+		out.aliases = make(map[string]string)
+
+		for alias, canonical := range o.aliases {
+			out.aliases[out.policy.apply(alias)] = out.policy.apply(canonical)
+		}
+	}
+
+	return out
+}
+
+// normalize returns the canonical, normalised form of the given header.
+func (n headerNormalizer) normalize(header string) string {
+	header = n.policy.apply(header)
+
+	if canonical, exist := n.aliases[header]; exist {
+		return canonical
+	}
+
+	return header
+}
@@ -0,0 +1,79 @@
+package fusereader
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderNormalization_apply(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy HeaderNormalization
+		header string
+		want   string
+	}{
+		{name: "None", header: " Allergen  Type Code ", want: " Allergen  Type Code "},
+		{name: "Trim", policy: HeaderNormalization{TrimSpace: true}, header: " Allergen  Type Code ", want: "Allergen  Type Code"},
+		{name: "Collapse", policy: HeaderNormalization{CollapseSpace: true}, header: " Allergen \t Type Code ", want: " Allergen Type Code "},
+		{name: "Fold", policy: HeaderNormalization{FoldCase: true}, header: "Allergen Type CODE", want: "allergen type code"},
+		{name: "All", policy: HeaderNormalization{TrimSpace: true, FoldCase: true, CollapseSpace: true}, header: "  Allergen  Type CODE ", want: "allergen type code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.apply(tt.header))
+		})
+	}
+}
+
+func Test_headerNormalizer_pattern(t *testing.T) {
+	p := regexp.MustCompile(`^Item ID$`)
+
+	assert.Same(t, p, headerNormalizerFrom().pattern(p))
+
+	n := headerNormalizerFrom(NormalizeHeaders(HeaderNormalization{FoldCase: true}))
+	folded := n.pattern(p)
+	assert.True(t, folded.MatchString("item id"))
+	assert.Same(t, folded, n.pattern(p))
+}
+
+func TestNormalizedHeaderResolution(t *testing.T) {
+	row := []string{"record type ", "OPERATION", "IMPORT ITEM?", "Information Provider GLN", "Information Provider Name", "Item Type", "Item  ID"}
+	row = append(row, headerNewGroupIndicator, "Allergen Type Code ", "Containment Level")
+
+	path := newTestFile(t, [][]string{row})
+
+	policy := HeaderNormalization{TrimSpace: true, FoldCase: true, CollapseSpace: true}
+	aliases := map[string]string{"Containment Level": "Level Of Containment"}
+
+	err := buildCaches([]string{path}, NormalizeHeaders(policy), HeaderAliases(aliases))
+	defer closeFiles()
+	defer removeHeaderCaches()
+	require.Nil(t, err)
+
+	got, err := headerIndexFor(path, HeaderSpecification{Key: "Allergen Type Code", OthersInGroup: []string{"Level Of Containment"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 8, got)
+
+	got, err = headerIndexFor(path, HeaderSpecification{Key: "ITEM ID", OthersInGroup: []string{headerRecordType}})
+	assert.Nil(t, err)
+	assert.Equal(t, 6, got)
+
+	got, err = headerIndexFor(path, HeaderSpecification{Key: "containment level"})
+	assert.Nil(t, err)
+	assert.Equal(t, 9, got)
+
+	gotIndices, err := selectedHeaderIndices(path, HeaderSpecification{Pattern: regexp.MustCompile(`^Item ID$`)})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{6}, gotIndices)
+
+	gotIndices, err = selectedHeaderIndices(path, HeaderSpecification{MatchHeader: func(s string) bool { return s == "level of containment" }})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{9}, gotIndices)
+
+	gotIndices, err = selectedHeaderIndices(path, HeaderSpecification{Pattern: regexp.MustCompile(`type code$`), FanOut: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{8}, gotIndices)
+}
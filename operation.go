@@ -0,0 +1,96 @@
+package fusereader
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operation is the action a FUSE item row asks of its recipient, as given by the OPERATION column.
+type Operation string
+
+const (
+	OperationAdd     Operation = "ADD"     // OperationAdd creates the item.
+	OperationChange  Operation = "CHANGE"  // OperationChange replaces the item.
+	OperationDelete  Operation = "DELETE"  // OperationDelete removes the item.
+	OperationCorrect Operation = "CORRECT" // OperationCorrect corrects the item in place.
+)
+
+// ParseOperation returns the operation described by the given OPERATION value.  Case, surrounding whitespace and any
+// code-list description are ignored.
+func ParseOperation(s string) Operation {
+	return Operation(strings.ToUpper(ParseCodeValue(s).Code))
+}
+
+// importFlagged returns true if the given IMPORT ITEM? value flags an item for import.
+func importFlagged(s string) bool {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "Y", "YES", "TRUE":
+		return true
+	}
+
+	return false
+}
+
+// itemFilter decides, from the first row of an item, whether the item is read.
+type itemFilter struct {
+	providers        optionFilterProviders  // providers contains the information provider GLNs accepted.
+	filterProviders  bool                   // filterProviders is true if items are filtered by provider.
+	providerIndex    int                    // providerIndex is the zero-based index of the Information Provider GLN column.
+	importOnly       bool                   // importOnly is true if items not flagged for import are skipped.
+	importIndex      int                    // importIndex is the zero-based index of the IMPORT ITEM? column.
+	operations       optionFilterOperations // operations contains the operations accepted.
+	filterOperations bool                   // filterOperations is true if items are filtered by operation.
+	operationIndex   int                    // operationIndex is the zero-based index of the OPERATION column.
+}
+
+// newItemFilter returns the item filter described by the given options for the given file.
+func newItemFilter(file string, opts ...Option) (itemFilter, error) {
+	var f itemFilter
+	var err error
+
+	f.providers, f.filterProviders = filterProvidersFrom(opts...)
+	_, f.importOnly = importFlaggedOnlyFrom(opts...)
+	f.operations, f.filterOperations = filterOperationsFrom(opts...)
+
+	if f.filterProviders {
+		if f.providerIndex, err = headerIndex(file, headerInformationProviderGLN, []string{headerOperation}, 1); err != nil {
+			return itemFilter{}, &Error{Kind: ErrHeaderNotFound, File: file, Header: headerInformationProviderGLN, Err: err}
+		}
+	}
+
+	if f.importOnly {
+		if f.importIndex, err = headerIndex(file, headerImportItem, []string{headerOperation}, 1); err != nil {
+			return itemFilter{}, &Error{Kind: ErrHeaderNotFound, File: file, Header: headerImportItem, Err: err}
+		}
+	}
+
+	if f.filterOperations {
+		if f.operationIndex, err = headerIndex(file, headerOperation, []string{headerRecordType}, 1); err != nil {
+			return itemFilter{}, &Error{Kind: ErrHeaderNotFound, File: file, Header: headerOperation, Err: err}
+		}
+	}
+
+	return f, nil
+}
+
+// admits returns true if the item beginning with the given row is to be read.
+func (f itemFilter) admits(cells []string) bool {
+	return f.rejection(cells) == ""
+}
+
+// rejection returns the reason the item beginning with the given row is not to be read, or an empty string if it is.
+func (f itemFilter) rejection(cells []string) string {
+	if gln := cellAt(cells, f.providerIndex); f.filterProviders && !f.providers.accepts(gln) {
+		return fmt.Sprintf("provider %q is not accepted", gln)
+	}
+
+	if f.importOnly && !importFlagged(cellAt(cells, f.importIndex)) {
+		return "item is not flagged for import"
+	}
+
+	if op := ParseOperation(cellAt(cells, f.operationIndex)); f.filterOperations && !f.operations.accepts(op) {
+		return fmt.Sprintf("operation %q is not accepted", op)
+	}
+
+	return ""
+}
@@ -0,0 +1,74 @@
+package fusereader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOperation(t *testing.T) {
+	assert.Equal(t, OperationAdd, ParseOperation("ADD"))
+	assert.Equal(t, OperationChange, ParseOperation(" change "))
+	assert.Equal(t, OperationDelete, ParseOperation("DELETE -- Delete"))
+	assert.Equal(t, Operation(""), ParseOperation(""))
+}
+
+func TestReadItemsForItemFilters(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{name: "None", want: []string{"00011110603081", "10011110603088", "00077661003169"}},
+		{name: "Import flagged", opts: []Option{ImportFlaggedOnly()}, want: []string{"00011110603081", "00077661003169"}},
+		{name: "Change", opts: []Option{FilterOperations(OperationChange)}, want: []string{"10011110603088"}},
+		{name: "Delete", opts: []Option{FilterOperations(OperationDelete, OperationCorrect)}},
+		{name: "Combined", opts: []Option{ImportFlaggedOnly(), FilterOperations(OperationChange)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+
+			err := readItems([]string{path}, func(it Item) error {
+				got = append(got, it.ID)
+				return nil
+			}, tt.opts...)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetFieldsForOperation(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	locate := validFieldLocation()
+	locate.Field.Matches = func(s string) bool { return s != "" }
+
+	retrieve := validRetrieveSpec()
+	retrieve.Field.Matches = func(s string) bool { return s != "" }
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{locate}, []FieldRetrieval{retrieve}, c, FilterOperations(OperationChange))
+	assert.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "10011110603088", got[0].ItemID())
+		assert.Equal(t, OperationChange, got[0].Operation())
+	}
+
+	var items []Item
+	err = readItems([]string{path}, func(it Item) error {
+		items = append(items, it)
+		return nil
+	})
+	assert.Nil(t, err)
+	if assert.Len(t, items, 3) {
+		assert.Equal(t, OperationAdd, items[0].Operation)
+		assert.Equal(t, OperationChange, items[1].Operation)
+	}
+}
@@ -2,6 +2,7 @@ package fusereader
 
 import (
 	"context"
+	"sync"
 )
 
 // Option represents an optional argument that enables certain features.
@@ -90,3 +91,528 @@ type optionCacheOnDisk struct {
 func (o optionCacheOnDisk) id() optionID {
 	return idCacheOnDisk
 }
+
+// HeaderRowScanLimit sets the maximum number of rows that will be searched for the header row.
+//
+// Without this option, the first 5 rows are searched.
+func HeaderRowScanLimit(rows int) Option {
+	return &optionHeaderRowScanLimit{rows: rows}
+}
+
+// headerRowScanLimitFrom returns a header row scan limit option from the given options.
+//
+// If the given options do not contain a header row scan limit option, then the returned
+// boolean will be false.
+func headerRowScanLimitFrom(opts ...Option) (optionHeaderRowScanLimit, bool) {
+	var out optionHeaderRowScanLimit
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionHeaderRowScanLimit)
+	}
+
+	return out, ok
+}
+
+type optionHeaderRowScanLimit struct {
+	rows int
+}
+
+func (o optionHeaderRowScanLimit) id() optionID {
+	return idHeaderRowScanLimit
+}
+
+// HeaderRowRequires identifies the header row as the first row containing all of the given headers, in any
+// order or position.
+//
+// Without this option, the header row must begin with the standard FUSE headers, from RECORD TYPE to Item ID.
+func HeaderRowRequires(headers ...string) Option {
+	return &optionHeaderRowRequires{headers: headers}
+}
+
+// headerRowRequiresFrom returns a header row requires option from the given options.
+//
+// If the given options do not contain a header row requires option, then the returned
+// boolean will be false.
+func headerRowRequiresFrom(opts ...Option) (optionHeaderRowRequires, bool) {
+	var out optionHeaderRowRequires
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionHeaderRowRequires)
+	}
+
+	return out, ok
+}
+
+type optionHeaderRowRequires struct {
+	headers []string
+}
+
+func (o optionHeaderRowRequires) id() optionID {
+	return idHeaderRowRequires
+}
+
+// HeaderRowAt uses the given one-based row number as the header row, rather than searching for it.
+func HeaderRowAt(row int) Option {
+	return &optionHeaderRowAt{row: row}
+}
+
+// headerRowAtFrom returns a header row at option from the given options.
+//
+// If the given options do not contain a header row at option, then the returned
+// boolean will be false.
+func headerRowAtFrom(opts ...Option) (optionHeaderRowAt, bool) {
+	var out optionHeaderRowAt
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionHeaderRowAt)
+	}
+
+	return out, ok
+}
+
+type optionHeaderRowAt struct {
+	row int
+}
+
+func (o optionHeaderRowAt) id() optionID {
+	return idHeaderRowAt
+}
+
+// NormalizeHeaders applies the given normalisation policy to headers, both when header caches are built and when
+// header specifications are resolved.
+func NormalizeHeaders(policy HeaderNormalization) Option {
+	return &optionNormalizeHeaders{policy: policy}
+}
+
+// normalizeHeadersFrom returns a normalize headers option from the given options.
+//
+// If the given options do not contain a normalize headers option, then the returned
+// boolean will be false.
+func normalizeHeadersFrom(opts ...Option) (optionNormalizeHeaders, bool) {
+	var out optionNormalizeHeaders
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionNormalizeHeaders)
+	}
+
+	return out, ok
+}
+
+type optionNormalizeHeaders struct {
+	policy HeaderNormalization
+}
+
+func (o optionNormalizeHeaders) id() optionID {
+	return idNormalizeHeaders
+}
+
+// HeaderAliases maps alternate header names, as keys, to their canonical header names, as values.
+//
+// Alternate names are treated as their canonical names, both when header caches are built and when header
+// specifications are resolved.  Any normalisation policy is applied to both names before they are mapped.
+func HeaderAliases(aliases map[string]string) Option {
+	return &optionHeaderAliases{aliases: aliases}
+}
+
+// headerAliasesFrom returns a header aliases option from the given options.
+//
+// If the given options do not contain a header aliases option, then the returned
+// boolean will be false.
+func headerAliasesFrom(opts ...Option) (optionHeaderAliases, bool) {
+	var out optionHeaderAliases
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionHeaderAliases)
+	}
+
+	return out, ok
+}
+
+type optionHeaderAliases struct {
+	aliases map[string]string
+}
+
+func (o optionHeaderAliases) id() optionID {
+	return idHeaderAliases
+}
+
+// SegmentItems sets the rules by which worksheet rows are divided into items.
+func SegmentItems(boundaries ItemBoundaries) Option {
+	return &optionSegmentItems{boundaries: boundaries}
+}
+
+// segmentItemsFrom returns a segment items option from the given options.
+//
+// If the given options do not contain a segment items option, then the returned
+// boolean will be false.
+func segmentItemsFrom(opts ...Option) (optionSegmentItems, bool) {
+	var out optionSegmentItems
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionSegmentItems)
+	}
+
+	return out, ok
+}
+
+type optionSegmentItems struct {
+	boundaries ItemBoundaries
+}
+
+func (o optionSegmentItems) id() optionID {
+	return idSegmentItems
+}
+
+// ContinueOnFileError records per-file failures in the given report instead of aborting, so that the remaining files
+// are still processed.  The report must not be nil.
+func ContinueOnFileError(report *RunReport) Option {
+	return &optionContinueOnFileError{report: report}
+}
+
+// continueOnFileErrorFrom returns a continue on file error option from the given options.
+//
+// If the given options do not contain a continue on file error option, then the returned
+// boolean will be false.
+func continueOnFileErrorFrom(opts ...Option) (optionContinueOnFileError, bool) {
+	var out optionContinueOnFileError
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionContinueOnFileError)
+	}
+
+	return out, ok
+}
+
+type optionContinueOnFileError struct {
+	report *RunReport
+}
+
+func (o optionContinueOnFileError) id() optionID {
+	return idContinueOnFileError
+}
+
+// ReportWarnings sends non-fatal anomalies found while reading, such as ragged rows or duplicate item IDs, to the given
+// function.
+//
+// Calls are serialised, so the function need not be safe for concurrent use, but it should return promptly.
+func ReportWarnings(fn func(Warning)) Option {
+	return &optionReportWarnings{fn: fn, mu: &sync.Mutex{}}
+}
+
+// reportWarningsFrom returns a report warnings option from the given options.
+//
+// If the given options do not contain a report warnings option, then the returned
+// boolean will be false.
+func reportWarningsFrom(opts ...Option) (optionReportWarnings, bool) {
+	var out optionReportWarnings
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionReportWarnings)
+	}
+
+	return out, ok
+}
+
+type optionReportWarnings struct {
+	fn func(Warning)
+	mu *sync.Mutex
+}
+
+func (o optionReportWarnings) id() optionID {
+	return idReportWarnings
+}
+
+// warn sends the given warning to the option's function, if any.
+func (o optionReportWarnings) warn(w Warning) {
+	if o.fn == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.fn(w)
+}
+
+// ExplainItem makes Explain report, for the item with the given ID, which field locations rejected it.
+func ExplainItem(itemID string) Option {
+	return &optionExplainItem{itemID: itemID}
+}
+
+// explainItemFrom returns an explain item option from the given options.
+//
+// If the given options do not contain an explain item option, then the returned
+// boolean will be false.
+func explainItemFrom(opts ...Option) (optionExplainItem, bool) {
+	var out optionExplainItem
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionExplainItem)
+	}
+
+	return out, ok
+}
+
+type optionExplainItem struct {
+	itemID string
+}
+
+func (o optionExplainItem) id() optionID {
+	return idExplainItem
+}
+
+// LogTo sends structured log records describing the progress of a run to the given logger.
+func LogTo(logger Logger) Option {
+	return &optionLogTo{logger: logger}
+}
+
+// logToFrom returns a log to option from the given options.
+//
+// If the given options do not contain a log to option, then the returned
+// boolean will be false.
+func logToFrom(opts ...Option) (optionLogTo, bool) {
+	var out optionLogTo
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionLogTo)
+	}
+
+	return out, ok
+}
+
+type optionLogTo struct {
+	logger Logger
+}
+
+func (o optionLogTo) id() optionID {
+	return idLogTo
+}
+
+// ReportProgress calls the given hooks as files are read and parsed.
+//
+// Calls are serialised, so the hooks need not be safe for concurrent use, but they should return promptly.
+func ReportProgress(hooks ProgressHooks) Option {
+	return &optionReportProgress{hooks: hooks, mu: &sync.Mutex{}}
+}
+
+// reportProgressFrom returns a report progress option from the given options.
+//
+// If the given options do not contain a report progress option, then the returned
+// boolean will be false.
+func reportProgressFrom(opts ...Option) (optionReportProgress, bool) {
+	var out optionReportProgress
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionReportProgress)
+	}
+
+	return out, ok
+}
+
+type optionReportProgress struct {
+	hooks ProgressHooks
+	mu    *sync.Mutex
+}
+
+func (o optionReportProgress) id() optionID {
+	return idReportProgress
+}
+
+// ValidateCodes reports retrieved values whose code is absent from the code list of their header as warnings of kind
+// WarningUnknownCode.  Warnings are sent to the function given by ReportWarnings.
+func ValidateCodes(lists CodeLists) Option {
+	return &optionValidateCodes{lists: lists}
+}
+
+// validateCodesFrom returns a validate codes option from the given options.
+//
+// If the given options do not contain a validate codes option, then the returned
+// boolean will be false.
+func validateCodesFrom(opts ...Option) (optionValidateCodes, bool) {
+	var out optionValidateCodes
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionValidateCodes)
+	}
+
+	return out, ok
+}
+
+type optionValidateCodes struct {
+	lists CodeLists
+}
+
+func (o optionValidateCodes) id() optionID {
+	return idValidateCodes
+}
+
+// FlagInvalidGTINs reports items whose GTIN has an invalid format or check digit as warnings of kind
+// WarningInvalidGTIN.  Warnings are sent to the function given by ReportWarnings.
+func FlagInvalidGTINs() Option {
+	return &optionFlagInvalidGTINs{}
+}
+
+// flagInvalidGTINsFrom returns a flag invalid GTINs option from the given options.
+//
+// If the given options do not contain a flag invalid GTINs option, then the returned
+// boolean will be false.
+func flagInvalidGTINsFrom(opts ...Option) (optionFlagInvalidGTINs, bool) {
+	var out optionFlagInvalidGTINs
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionFlagInvalidGTINs)
+	}
+
+	return out, ok
+}
+
+type optionFlagInvalidGTINs struct{}
+
+func (o optionFlagInvalidGTINs) id() optionID {
+	return idFlagInvalidGTINs
+}
+
+// FilterProviders limits reading to items whose Information Provider GLN is one of the given GLNs.  Other items are
+// skipped by the reader before any parsing takes place.
+func FilterProviders(glns ...string) Option {
+	o := &optionFilterProviders{glns: make(map[string]bool, len(glns))}
+	for _, g := range glns {
+		o.glns[normalizeGLN(g)] = true
+	}
+
+	return o
+}
+
+// filterProvidersFrom returns a filter providers option from the given options.
+//
+// If the given options do not contain a filter providers option, then the returned
+// boolean will be false.
+func filterProvidersFrom(opts ...Option) (optionFilterProviders, bool) {
+	var out optionFilterProviders
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionFilterProviders)
+	}
+
+	return out, ok
+}
+
+type optionFilterProviders struct {
+	glns map[string]bool
+}
+
+func (o optionFilterProviders) id() optionID {
+	return idFilterProviders
+}
+
+// accepts returns true if the given GLN is one of the option's GLNs.
+func (o optionFilterProviders) accepts(gln string) bool {
+	return o.glns[normalizeGLN(gln)]
+}
+
+// ImportFlaggedOnly limits reading to items whose IMPORT ITEM? column is Y.  Other items are skipped by the reader
+// before any parsing takes place.
+func ImportFlaggedOnly() Option {
+	return &optionImportFlaggedOnly{}
+}
+
+// importFlaggedOnlyFrom returns an import flagged only option from the given options.
+//
+// If the given options do not contain an import flagged only option, then the returned
+// boolean will be false.
+func importFlaggedOnlyFrom(opts ...Option) (optionImportFlaggedOnly, bool) {
+	var out optionImportFlaggedOnly
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionImportFlaggedOnly)
+	}
+
+	return out, ok
+}
+
+type optionImportFlaggedOnly struct{}
+
+func (o optionImportFlaggedOnly) id() optionID {
+	return idImportFlaggedOnly
+}
+
+// FilterOperations limits reading to items whose OPERATION is one of the given operations.  Other items are skipped by
+// the reader before any parsing takes place.
+func FilterOperations(ops ...Operation) Option {
+	o := &optionFilterOperations{ops: make(map[Operation]bool, len(ops))}
+	for _, op := range ops {
+		o.ops[ParseOperation(string(op))] = true
+	}
+
+	return o
+}
+
+// filterOperationsFrom returns a filter operations option from the given options.
+//
+// If the given options do not contain a filter operations option, then the returned
+// boolean will be false.
+func filterOperationsFrom(opts ...Option) (optionFilterOperations, bool) {
+	var out optionFilterOperations
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionFilterOperations)
+	}
+
+	return out, ok
+}
+
+type optionFilterOperations struct {
+	ops map[Operation]bool
+}
+
+func (o optionFilterOperations) id() optionID {
+	return idFilterOperations
+}
+
+// accepts returns true if the given operation is one of the option's operations.
+func (o optionFilterOperations) accepts(op Operation) bool {
+	return o.ops[op]
+}
+
+// KeyDuplicatesByProvider makes FindDuplicates treat items as duplicates only if both their Item ID and Information
+// Provider GLN match.
+func KeyDuplicatesByProvider() Option {
+	return &optionKeyDuplicatesByProvider{}
+}
+
+// keyDuplicatesByProviderFrom returns a key duplicates by provider option from the given options.
+//
+// If the given options do not contain a key duplicates by provider option, then the returned
+// boolean will be false.
+func keyDuplicatesByProviderFrom(opts ...Option) (optionKeyDuplicatesByProvider, bool) {
+	var out optionKeyDuplicatesByProvider
+
+	i, ok := optionIndex(out, opts)
+	if ok {
+		out = *opts[i].(*optionKeyDuplicatesByProvider)
+	}
+
+	return out, ok
+}
+
+type optionKeyDuplicatesByProvider struct{}
+
+func (o optionKeyDuplicatesByProvider) id() optionID {
+	return idKeyDuplicatesByProvider
+}
@@ -3,6 +3,7 @@ package fusereader
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -18,12 +19,41 @@ type parseTarget struct {
 	file         string     // file is the filename of the originating spreadsheet that rowContents was read from.
 	beginningRow int        // beginningRow is the first row in the originating spreadsheet that rowContents was read from.
 	rowContents  [][]string // rowContents are the rows for a particular item as read from the spreadsheet.
+	rawContents  [][]string // rawContents are the rows of rowContents without number formats applied.
 }
 
 // parseWorker identifies matching items in the given parse buffer and sends retrieved fields to the given retrieval buffer.
 //
-// The given file should match the file being read by the function sending into the parse buffer.
-func parseWorker(file string, locate []FieldLocation, retrieve []FieldRetrieval, parseBuffer chan parseTarget, retrieveBuffer chan field) error {
+// The given file should match the file being read by the function sending into the parse buffer.  The key headers of
+// each specification are resolved once, before any items are parsed.
+func parseWorker(file string, locate []FieldLocation, retrieve []FieldRetrieval, parseBuffer chan parseTarget, retrieveBuffer chan field, opts ...Option) error {
+	progress := observerFrom(opts...)
+
+	locateIndices := make(map[string][]int, len(locate))
+	for _, l := range locate {
+		indices, err := resolveHeaders(progress, file, l.ID, l.Header)
+		if err != nil {
+			return err
+		}
+
+		locateIndices[l.ID] = indices
+	}
+
+	retrieveIndices := make(map[string][]int, len(retrieve))
+	for _, r := range retrieve {
+		indices, err := resolveHeaders(progress, file, r.ID, r.Header)
+		if err != nil {
+			return err
+		}
+
+		retrieveIndices[r.ID] = indices
+	}
+
+	itemIDIndex, err := headerIndex(file, headerItemID, []string{headerOperation}, 1)
+	if err != nil {
+		return &Error{Kind: ErrHeaderNotFound, File: file, Header: headerItemID, Err: err}
+	}
+
 	for {
 		select {
 		case v, ok := <-parseBuffer:
@@ -31,51 +61,72 @@ func parseWorker(file string, locate []FieldLocation, retrieve []FieldRetrieval,
 				return nil
 			}
 
-			matches, err := parseMatch(file, v, locate)
+			matches, err := parseMatch(file, v, locate, locateIndices)
 			if err != nil {
 				return fmt.Errorf("error while checking for parse match in %s: %w", file, err)
 			}
 
 			if matches {
-				if err := parseRetrieve(file, v, retrieve, retrieveBuffer); err != nil {
-					return fmt.Errorf("error while parsing to retrieve values")
+				progress.itemMatched(file, cellAt(v.rowContents[0], itemIDIndex), v.beginningRow)
+
+				if err := parseRetrieve(file, v, locate, retrieve, retrieveIndices, retrieveBuffer, opts...); err != nil {
+					return fmt.Errorf("error while parsing to retrieve values: %w", err)
 				}
 			}
 
 		case <-time.After(parseBufferReceiveTimeout):
-			return fmt.Errorf("parse buffer timed out while waiting for receipt")
+			return &Error{Kind: ErrTimeout, File: file, Err: fmt.Errorf("parse buffer timed out while waiting for receipt")}
 		}
 
 	}
 }
 
-// parseMatch returns true if fields contains fields specified by the contents of find.
-func parseMatch(filename string, target parseTarget, find []FieldLocation) (bool, error) {
-	indexCache := make(map[string]int)
-	specIndices := make(map[string]int)
+// resolveHeaders returns the key header indices of the given header specification within the given file, reporting
+// each to the given observer.
+func resolveHeaders(progress observer, file, specID string, header HeaderSpecification) ([]int, error) {
+	indices, err := headerIndicesFor(file, header)
+	if err != nil {
+		return nil, &Error{Kind: ErrHeaderNotFound, File: file, SpecID: specID, Header: header.describe(), Err: err}
+	}
+
+	for _, index := range indices {
+		progress.headerResolved(file, specID, index)
+	}
+
+	return indices, nil
+}
+
+// parseMatch returns true if fields contains fields specified by the contents of find, whose key header indices are
+// given by resolved.
+func parseMatch(filename string, target parseTarget, find []FieldLocation, resolved map[string][]int) (bool, error) {
+	indexCache := make(map[string][]int, len(find))
+	specIndices := make(map[string]int, len(find))
 
 	for i, spec := range find {
-		index, err := headerIndex(filename, spec.Header.Key, spec.Header.OthersInGroup, spec.Header.OnMatch)
-		if err != nil {
-			return false, fmt.Errorf("error while getting index of key header for spec %s in file %s: %w", spec.ID, filepath.Base(filename), err)
+		indices, exist := resolved[spec.ID]
+		if !exist {
+			return false, fmt.Errorf("the key headers of spec %s have not been resolved in %s", spec.ID, filepath.Base(filename))
 		}
 
-		indexCache[spec.ID] = index
+		indexCache[spec.ID] = indices
 		specIndices[spec.ID] = i
 	}
 
 	for _, row := range target.rowContents {
-		for specID, index := range indexCache {
-			if len(row) <= index {
-				continue
-			}
+		for specID, indices := range indexCache {
+			for _, index := range indices {
+				if len(row) <= index {
+					continue
+				}
 
-			if find[specIndices[specID]].Field.Matches(row[index]) {
-				find[specIndices[specID]].Field.matchCount++
+				if find[specIndices[specID]].Field.Matches(row[index]) {
+					find[specIndices[specID]].Field.matchCount++
 
-				if find[specIndices[specID]].Field.matchCount >= int(find[specIndices[specID]].Field.OnMatch) {
-					delete(indexCache, specID)
-					delete(specIndices, specID)
+					if find[specIndices[specID]].Field.matchCount >= int(find[specIndices[specID]].Field.OnMatch) {
+						delete(indexCache, specID)
+						delete(specIndices, specID)
+						break
+					}
 				}
 			}
 		}
@@ -84,13 +135,24 @@ func parseMatch(filename string, target parseTarget, find []FieldLocation) (bool
 	return len(indexCache) == 0, nil
 }
 
-// parseRetrieve retrieves values specified by retrieve and sends them over the given buffer.
-func parseRetrieve(filename string, target parseTarget, retrieve []FieldRetrieval, buffer chan field) error {
+// parseRetrieve retrieves values specified by retrieve, from an item selected by locate, and sends them over the given
+// buffer.
+//
+// The key header indices of each retrieval are given by resolved.  Retrieved values are checked against any code lists
+// within opts.
+func parseRetrieve(filename string, target parseTarget, locate []FieldLocation, retrieve []FieldRetrieval, resolved map[string][]int, buffer chan field, opts ...Option) error {
 	fieldToSend := field{}
+	codes, validate := validateCodesFrom(opts...)
+	warnings, _ := reportWarningsFrom(opts...)
+
+	locateSpecIDs := make([]string, len(locate))
+	for i, l := range locate {
+		locateSpecIDs[i] = l.ID
+	}
 
 	index, err := headerIndex(filename, headerItemID, []string{headerOperation}, 1)
 	if err != nil {
-		return fmt.Errorf("error while getting index for header %s in %s: %w", headerItemID, filepath.Base(filename), err)
+		return &Error{Kind: ErrHeaderNotFound, File: filename, Header: headerItemID, Err: err}
 	}
 
 	if len(target.rowContents[0]) < index {
@@ -98,53 +160,80 @@ func parseRetrieve(filename string, target parseTarget, retrieve []FieldRetrieva
 	}
 
 	fieldToSend.SetItemID(target.rowContents[0][index])
+	fieldToSend.SetOperation(itemOperation(filename, target.rowContents[0]))
 
-	specIndices := make(map[string]int)
-	indexCache := make(map[string]int)
+	specIndices := make(map[string]int, len(retrieve))
 
 	for i, r := range retrieve {
-		specIndices[r.ID] = i
-
-		index, err := headerIndex(filename, r.Header.Key, r.Header.OthersInGroup, r.Header.OnMatch)
-		if err != nil {
-			return fmt.Errorf("error while getting index of key header for spec %s in file %s: %w", r.ID, filepath.Base(filename), err)
+		if _, exist := resolved[r.ID]; !exist {
+			return fmt.Errorf("the key headers of spec %s have not been resolved in %s", r.ID, filepath.Base(filename))
 		}
 
-		indexCache[r.ID] = index
+		specIndices[r.ID] = i
 	}
 
 	for i, row := range target.rowContents {
 		for specID, specIndex := range specIndices {
-			if len(row) <= indexCache[specID] {
-				continue
-			}
+			for _, keyIndex := range resolved[specID] {
+				if len(row) <= keyIndex {
+					continue
+				}
+
+				if !retrieve[specIndex].Field.Matches(row[keyIndex]) {
+					continue
+				}
 
-			if retrieve[specIndex].Field.Matches(row[indexCache[specID]]) {
 				retrieve[specIndex].Field.matchCount++
 
-				if retrieve[specIndex].Field.matchCount >= int(retrieve[specIndex].Field.OnMatch) {
-					fieldToSend.SetFile(target.file)
+				if retrieve[specIndex].Field.matchCount < int(retrieve[specIndex].Field.OnMatch) {
+					continue
+				}
 
-					fieldToSend.SetHeader(retrieve[specIndex].Header.Key)
-					fieldToSend.SetSpecID(retrieve[specIndex].ID)
+				fieldToSend.SetFile(target.file)
 
-					for _, offset := range retrieve[specIndex].FieldOffsets {
-						fieldToSend.SetValue(row[indexCache[specID]+offset])
+				fieldToSend.SetHeader(headerTextAt(filename, keyIndex))
+				fieldToSend.SetSpecID(retrieve[specIndex].ID)
 
-						a, err := excelize.CoordinatesToCellName(indexCache[specID]+offset+1, target.beginningRow+i)
-						if err != nil {
-							return fmt.Errorf("error while converting column %d and row %d to a cell name: %w", indexCache[specID]+offset, target.beginningRow, err)
-						}
+				for _, offset := range retrieve[specIndex].FieldOffsets {
+					fieldToSend.SetValue(cellAt(row, keyIndex+offset))
+
+					a, err := excelize.CoordinatesToCellName(keyIndex+offset+1, target.beginningRow+i)
+					if err != nil {
+						return fmt.Errorf("error while converting column %d and row %d to a cell name: %w", keyIndex+offset, target.beginningRow, err)
+					}
 
-						fieldToSend.SetAddress(a)
+					fieldToSend.SetAddress(a)
 
-						select {
-						case buffer <- fieldToSend:
-						case <-time.After(retrieveBufferSendTimeout):
-							return fmt.Errorf("timeout while waiting to send to retrieve buffer for spec ID %s in %s", retrieve[specIndex].ID, filepath.Base(filename))
+					raw := cellAt(target.rawRow(i), keyIndex+offset)
+					fieldToSend.SetRawValue(raw)
+					fieldToSend.SetCellType(knownCellType(raw, fieldToSend.Value()))
+					fieldToSend.SetUnit("")
+
+					if retrieve[specIndex].UnitHeader != "" {
+						if unitIndex, found := siblingHeaderIndex(filename, retrieve[specIndex].UnitHeader, keyIndex+offset); found {
+							fieldToSend.SetUnit(cellAt(row, unitIndex))
 						}
 					}
 
+					if validate {
+						warnUnknownCode(warnings, codes.lists, filename, target.beginningRow+i, a, headerTextAt(filename, keyIndex+offset), fieldToSend.Value())
+					}
+					fieldToSend.SetProvenance(Provenance{
+						Sheet:           worksheetFSItem,
+						Row:             target.beginningRow + i - 1,
+						Column:          keyIndex + offset,
+						GroupRoot:       groupRootOf(filename, keyIndex),
+						GroupOccurrence: headerOccurrence(filename, keyIndex),
+						LocateSpecIDs:   append([]string(nil), locateSpecIDs...),
+						MatchOccurrence: retrieve[specIndex].Field.matchCount,
+						Offset:          offset,
+					})
+
+					select {
+					case buffer <- fieldToSend:
+					case <-time.After(retrieveBufferSendTimeout):
+						return &Error{Kind: ErrTimeout, File: filename, SpecID: retrieve[specIndex].ID, Header: fieldToSend.Header(), Cell: a, Err: fmt.Errorf("timeout while waiting to send to retrieve buffer")}
+					}
 				}
 			}
 		}
@@ -152,3 +241,82 @@ func parseRetrieve(filename string, target parseTarget, retrieve []FieldRetrieva
 
 	return nil
 }
+
+// itemOperation returns the operation within the given first row of an item in the given file, or an empty operation
+// if the file lacks an OPERATION header.
+func itemOperation(file string, firstRow []string) Operation {
+	index, err := headerIndex(file, headerOperation, []string{headerRecordType}, 1)
+	if err != nil {
+		return ""
+	}
+
+	return ParseOperation(cellAt(firstRow, index))
+}
+
+// warnUnknownCode sends a warning if the code within the given value, found under the given header, is absent from the
+// header's code list.  Empty values are not checked.
+func warnUnknownCode(warnings optionReportWarnings, lists CodeLists, file string, row int, address, header, value string) {
+	code := ParseCodeValue(value).Code
+	if code == "" || lists.known(headerRule.names, header, code) {
+		return
+	}
+
+	warnings.warn(Warning{Kind: WarningUnknownCode, File: file, Row: row, Cell: address, Message: fmt.Sprintf("code %s is not in the code list for %s", code, header)})
+}
+
+// knownCellType returns the data type of a cell where it can be determined from the cell's raw and formatted contents,
+// as the streaming row reader does not expose the type recorded in the spreadsheet.  CellTypeUnset is returned where
+// the type cannot be determined.
+//
+// Booleans are recognised by their formatting and numbers by a number format having changed their contents.  Raw
+// contents that are not numeric are text, unless they are an error value.  Numbers without a number format cannot be
+// told apart from numeric text, such as GTINs, and so are not typed.
+func knownCellType(raw, formatted string) excelize.CellType {
+	switch {
+	case raw == "":
+		return excelize.CellTypeUnset
+	case (raw == "0" || raw == "1") && (formatted == "FALSE" || formatted == "TRUE"):
+		return excelize.CellTypeBool
+	case cellErrorValues[raw]:
+		return excelize.CellTypeUnset
+	}
+
+	if _, err := strconv.ParseFloat(raw, 64); err != nil {
+		return excelize.CellTypeString
+	} else if formatted != raw {
+		return excelize.CellTypeNumber
+	}
+
+	return excelize.CellTypeUnset
+}
+
+// cellErrorValues contains the values of cells holding formula errors, which may also be stored as text.
+var cellErrorValues = map[string]bool{
+	"#NULL!":  true,
+	"#DIV/0!": true,
+	"#VALUE!": true,
+	"#REF!":   true,
+	"#NAME?":  true,
+	"#NUM!":   true,
+	"#N/A":    true,
+}
+
+// rawRow returns the raw contents of the given zero-based row of the target, or nil if they were not read.
+func (t parseTarget) rawRow(i int) []string {
+	if i >= len(t.rawContents) {
+		return nil
+	}
+
+	return t.rawContents[i]
+}
+
+// cellAt returns the contents of the cell at the given zero-based index of the given row.
+//
+// As trailing empty cells are omitted from rows, an index beyond the end of the row results in an empty string.
+func cellAt(row []string, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+
+	return row[index]
+}
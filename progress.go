@@ -0,0 +1,124 @@
+package fusereader
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+const (
+	rowsReadInterval = 1000 // rowsReadInterval is the number of rows read between progress reports.
+)
+
+// Logger receives structured log records.  Implementations must be safe for concurrent use.
+//
+// keysAndValues alternates between string keys and their values, as with most structured logging libraries.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{}) // Debug records detailed progress, such as resolved headers.
+	Info(msg string, keysAndValues ...interface{})  // Info records major progress, such as files being opened or completed.
+	Error(msg string, keysAndValues ...interface{}) // Error records failures.
+}
+
+// ProgressHooks contains functions called as files are read and parsed.  Any of the functions may be nil.
+type ProgressHooks struct {
+	OnFileOpened     func(file string)                    // OnFileOpened is called when a file begins to be read.
+	OnHeaderResolved func(file, specID string, index int) // OnHeaderResolved is called with the zero-based index of each key header resolved for a specification.
+	OnRowsRead       func(file string, rows int)          // OnRowsRead is called periodically with the total number of rows read from a file so far, and once the file has been read.
+	OnItemMatched    func(file, itemID string, row int)   // OnItemMatched is called with the ID and one-based beginning row of each item matching every field location.
+	OnFileDone       func(file string, err error)         // OnFileDone is called once a file has been read and parsed, with the error that ended processing, if any.
+}
+
+// observer forwards progress to a logger and progress hooks, if present.
+type observer struct {
+	logger Logger        // logger receives log records, if non-nil.
+	hooks  ProgressHooks // hooks receives progress calls.
+	mu     *sync.Mutex   // mu serialises hook calls made from concurrent workers.
+}
+
+// observerFrom returns an observer for the logger and progress hooks within the given options.
+func observerFrom(opts ...Option) observer {
+	out := observer{mu: &sync.Mutex{}}
+
+	if o, ok := logToFrom(opts...); ok {
+		out.logger = o.logger
+	}
+
+	if o, ok := reportProgressFrom(opts...); ok {
+		out.hooks, out.mu = o.hooks, o.mu
+	}
+
+	return out
+}
+
+// fileOpened reports that the given file has begun to be read.
+func (o observer) fileOpened(file string) {
+	if o.logger != nil {
+		o.logger.Info("reading file", "file", filepath.Base(file))
+	}
+
+	if o.hooks.OnFileOpened != nil {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		o.hooks.OnFileOpened(file)
+	}
+}
+
+// headerResolved reports that a key header of the given spec has been resolved to the given index.
+func (o observer) headerResolved(file, specID string, index int) {
+	if o.logger != nil {
+		o.logger.Debug("resolved header", "file", filepath.Base(file), "spec", specID, "index", index, "header", headerTextAt(file, index))
+	}
+
+	if o.hooks.OnHeaderResolved != nil {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		o.hooks.OnHeaderResolved(file, specID, index)
+	}
+}
+
+// rowsRead reports the total number of rows read from the given file so far.
+func (o observer) rowsRead(file string, rows int) {
+	if o.logger != nil {
+		o.logger.Debug("read rows", "file", filepath.Base(file), "rows", rows)
+	}
+
+	if o.hooks.OnRowsRead != nil {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		o.hooks.OnRowsRead(file, rows)
+	}
+}
+
+// itemMatched reports that the item with the given ID and beginning row matched every field location.
+func (o observer) itemMatched(file, itemID string, row int) {
+	if o.logger != nil {
+		o.logger.Debug("matched item", "file", filepath.Base(file), "item", itemID, "row", row)
+	}
+
+	if o.hooks.OnItemMatched != nil {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		o.hooks.OnItemMatched(file, itemID, row)
+	}
+}
+
+// fileDone reports that the given file has been read and parsed, ending with the given error.
+func (o observer) fileDone(file string, err error) {
+	if o.logger != nil {
+		if err != nil {
+			o.logger.Error("failed to process file", "file", filepath.Base(file), "error", err)
+		} else {
+			o.logger.Info("processed file", "file", filepath.Base(file))
+		}
+	}
+
+	if o.hooks.OnFileDone != nil {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		o.hooks.OnFileDone(file, err)
+	}
+}
@@ -0,0 +1,62 @@
+package fusereader
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger records the messages it receives.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.messages = append(l.messages, msg)
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) { l.record(msg) }
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{})  { l.record(msg) }
+func (l *recordingLogger) Error(msg string, keysAndValues ...interface{}) { l.record(msg) }
+
+func TestGetFieldsReportProgress(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	var opened, done []string
+	var resolved []int
+	var rowsRead []int
+	var matched []string
+
+	hooks := ProgressHooks{
+		OnFileOpened:     func(file string) { opened = append(opened, file) },
+		OnHeaderResolved: func(file, specID string, index int) { resolved = append(resolved, index) },
+		OnRowsRead:       func(file string, rows int) { rowsRead = append(rowsRead, rows) },
+		OnItemMatched:    func(file, itemID string, row int) { matched = append(matched, itemID) },
+		OnFileDone: func(file string, err error) {
+			assert.Nil(t, err)
+			done = append(done, file)
+		},
+	}
+
+	logger := &recordingLogger{}
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, c, ReportProgress(hooks), LogTo(logger))
+	require.Nil(t, err)
+	close(c)
+
+	assert.Equal(t, []string{path}, opened)
+	assert.Equal(t, []int{6, 8}, resolved)
+	assert.Equal(t, []int{5}, rowsRead)
+	assert.Equal(t, []string{"00011110603081"}, matched)
+	assert.Equal(t, []string{path}, done)
+
+	assert.Contains(t, logger.messages, "reading file")
+	assert.Contains(t, logger.messages, "processed file")
+}
@@ -0,0 +1,125 @@
+package fusereader
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Kindred87/fusereader/gln"
+)
+
+// ProviderSummary describes the number of items from each information provider within each file.
+type ProviderSummary struct {
+	Files []FileProviders `json:"files"` // Files contains the summary of each file, in the order given.
+}
+
+// FileProviders describes the number of items from each information provider within a file.
+type FileProviders struct {
+	File      string          `json:"file"`      // File is the path of the file.
+	Providers []ProviderCount `json:"providers"` // Providers contains the item count of each provider, ordered by GLN.
+}
+
+// ProviderCount describes the number of items from an information provider.
+type ProviderCount struct {
+	GLN      string `json:"gln"`      // GLN is the provider's Information Provider GLN, as written in the file.
+	Name     string `json:"name"`     // Name is the provider's Information Provider Name, as written on the provider's first item.
+	ValidGLN bool   `json:"validGLN"` // ValidGLN is true if the GLN's format and check digit are valid.
+	Items    int    `json:"items"`    // Items is the number of the provider's items within the file.
+}
+
+// SummarizeProviders returns the number of items from each information provider within each of the given files,
+// reading each file in a single pass.
+func SummarizeProviders(files []string, opts ...Option) (ProviderSummary, error) {
+	summary := ProviderSummary{Files: []FileProviders{}}
+	counts := make(map[string]map[string]*ProviderCount)
+
+	err := readItems(files, func(it Item) error {
+		byGLN, exist := counts[it.File]
+		if !exist {
+			byGLN = make(map[string]*ProviderCount)
+			counts[it.File] = byGLN
+		}
+
+		provider := strings.TrimSpace(it.Value(headerInformationProviderGLN))
+
+		c, exist := byGLN[normalizeGLN(provider)]
+		if !exist {
+			c = &ProviderCount{GLN: provider, Name: it.Value(headerInformationProviderName), ValidGLN: gln.Valid(provider)}
+			byGLN[normalizeGLN(provider)] = c
+		}
+
+		c.Items++
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return ProviderSummary{}, fmt.Errorf("error while reading items: %w", err)
+	}
+
+	for _, file := range files {
+		fp := FileProviders{File: file, Providers: []ProviderCount{}}
+
+		for _, c := range counts[file] {
+			fp.Providers = append(fp.Providers, *c)
+		}
+
+		sort.Slice(fp.Providers, func(i, j int) bool { return fp.Providers[i].GLN < fp.Providers[j].GLN })
+		summary.Files = append(summary.Files, fp)
+	}
+
+	return summary, nil
+}
+
+// Items returns the number of items from the provider with the given GLN within the given file.
+func (s ProviderSummary) Items(file, providerGLN string) int {
+	for _, f := range s.Files {
+		if f.File != file {
+			continue
+		}
+
+		for _, p := range f.Providers {
+			if normalizeGLN(p.GLN) == normalizeGLN(providerGLN) {
+				return p.Items
+			}
+		}
+	}
+
+	return 0
+}
+
+// JSON returns the summary encoded as indented JSON.
+func (s ProviderSummary) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// String returns a human-readable description of the summary, with a line per provider per file.
+func (s ProviderSummary) String() string {
+	var b strings.Builder
+
+	for _, f := range s.Files {
+		fmt.Fprintf(&b, "%s\n", filepath.Base(f.File))
+
+		for _, p := range f.Providers {
+			invalid := ""
+			if !p.ValidGLN {
+				invalid = " (invalid GLN)"
+			}
+
+			fmt.Fprintf(&b, "  %s %s%s: %d items\n", p.GLN, p.Name, invalid, p.Items)
+		}
+	}
+
+	return b.String()
+}
+
+// normalizeGLN returns the given GLN in the form used for comparison, falling back to the trimmed GLN if it is
+// malformed.
+func normalizeGLN(g string) string {
+	if n, err := gln.Normalize(g); err == nil {
+		return n
+	}
+
+	return strings.TrimSpace(g)
+}
@@ -0,0 +1,76 @@
+package fusereader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFieldsForFilterProviders(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	retrieve := validRetrieveSpec()
+	retrieve.Field.Matches = func(s string) bool { return s != "" }
+
+	locate := validFieldLocation()
+	locate.Field.Matches = func(s string) bool { return s != "" }
+
+	tests := []struct {
+		name      string
+		providers []string
+		want      []string
+	}{
+		{name: "Acme", providers: []string{"0614141000012"}, want: []string{"00011110603081", "00077661003169"}},
+		{name: "Globex", providers: []string{" 0614141000029 "}, want: []string{"10011110603088"}},
+		{name: "Unknown", providers: []string{"0000000000000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := make(chan field, 10)
+			err := GetFields([]string{path}, []FieldLocation{locate}, []FieldRetrieval{retrieve}, c, FilterProviders(tt.providers...))
+			assert.Nil(t, err)
+			close(c)
+
+			var got []string
+			for _, f := range collectFields(c) {
+				if len(got) == 0 || got[len(got)-1] != f.ItemID() {
+					got = append(got, f.ItemID())
+				}
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSummarizeProviders(t *testing.T) {
+	rows := itemTestRows()
+	rows = append(rows, []string{itemRecordType, "ADD", "Y", "0614141000013", "Initech", "GTIN", "00011110603081"})
+	path := newTestFile(t, rows)
+
+	got, err := SummarizeProviders([]string{path})
+	assert.Nil(t, err)
+
+	assert.Equal(t, ProviderSummary{Files: []FileProviders{{
+		File: path,
+		Providers: []ProviderCount{
+			{GLN: "0614141000012", Name: "Acme", ValidGLN: true, Items: 2},
+			{GLN: "0614141000013", Name: "Initech", ValidGLN: false, Items: 1},
+			{GLN: "0614141000029", Name: "Globex", ValidGLN: true, Items: 1},
+		},
+	}}}, got)
+	assert.Equal(t, 2, got.Items(path, "0614141000012"))
+	assert.Contains(t, got.String(), "0614141000013 Initech (invalid GLN): 1 items")
+
+	got, err = SummarizeProviders([]string{path}, FilterProviders("0614141000029"))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, got.Items(path, "0614141000012"))
+	assert.Equal(t, 1, got.Items(path, "0614141000029"))
+
+	got, err = SummarizeProviders([]string{path}, FilterProviders("0614141000036"))
+	assert.Nil(t, err)
+
+	b, err := got.JSON()
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"providers": []`)
+}
@@ -1,19 +1,38 @@
 package fusereader
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"time"
+
+	"github.com/xuri/excelize/v2"
 )
 
-const (
-	emptyRowMax            = 50
-	parseBufferSendTimeout = time.Millisecond * 2000
+const emptyRowMax = 50
+
+var (
+	errReadCancelled       = errors.New("read cancelled") // errReadCancelled is returned by readSegments when reading is cancelled by its caller.
+	parseBufferSendTimeout = time.Millisecond * 2000      // parseBufferSendTimeout is how long the reader waits to send an item to the parse buffer.  It is a variable so that tests may shorten it.
 )
 
 // readWorker reads items in the given file, sending items containing values matching the given specification to the parse
 // buffer.
-func readWorker(file string, locate FieldLocation, parseBuffer chan parseTarget) error {
+//
+// Rows are divided into items as described by any item boundaries within opts.  Items rejected by any provider, import
+// or operation filters within opts are skipped.
+func readWorker(file string, locate FieldLocation, parseBuffer chan parseTarget, opts ...Option) error {
+	return readSegments(file, locate, parseBuffer, nil, true, opts...)
+}
+
+// readSegments performs the work of readWorker.
+//
+// If done is nil, sending to the parse buffer times out after parseBufferSendTimeout.  Otherwise, sending blocks until
+// the item is received or done is closed, in which case errReadCancelled is returned.
+//
+// If readRaw is true, each row is also read without number formats applied, which requires a second pass over the
+// worksheet and so should only be requested when fields are to be retrieved.
+func readSegments(file string, locate FieldLocation, parseBuffer chan parseTarget, done <-chan struct{}, readRaw bool, opts ...Option) error {
 	defer close(parseBuffer)
 
 	fi, err := getFile(file)
@@ -21,83 +40,173 @@ func readWorker(file string, locate FieldLocation, parseBuffer chan parseTarget)
 		return fmt.Errorf("error while getting file pointer for %s: %w", filepath.Base(file), err)
 	}
 
-	keyHeaderIndex, err := headerIndex(file, locate.Header.Key, locate.Header.OthersInGroup, locate.Header.OnMatch)
+	progress := observerFrom(opts...)
+	progress.fileOpened(file)
+
+	keyHeaderIndices, err := headerIndicesFor(file, locate.Header)
 	if err != nil {
-		return fmt.Errorf("error while determining key header index for FieldSpecification %s: %w", locate.ID, err)
+		return &Error{Kind: ErrHeaderNotFound, File: file, SpecID: locate.ID, Header: locate.Header.describe(), Err: err}
 	}
 
 	recordTypeIndex, err := headerIndex(file, headerRecordType, []string{headerOperation}, 1)
 	if err != nil {
-		return fmt.Errorf("error while determining index of header %s: %w", headerRecordType, err)
+		return &Error{Kind: ErrHeaderNotFound, File: file, Header: headerRecordType, Err: err}
+	}
+
+	itemIDIndex, err := headerIndex(file, headerItemID, []string{headerOperation}, 1)
+	if err != nil {
+		return &Error{Kind: ErrHeaderNotFound, File: file, Header: headerItemID, Err: err}
+	}
+
+	itemTypeIndex, err := headerIndex(file, headerItemType, []string{headerOperation}, 1)
+	if err != nil {
+		itemTypeIndex = -1
+	}
+
+	filter, err := newItemFilter(file, opts...)
+	if err != nil {
+		return err
 	}
 
 	rows, err := fi.Rows(worksheetFSItem)
 	if err != nil {
 		return fmt.Errorf("error while getting row iterator for %s: %w", filepath.Base(file), err)
 	}
+	defer rows.Close()
+
+	var rawRows *excelize.Rows
+	if readRaw {
+		if rawRows, err = fi.Rows(worksheetFSItem); err != nil {
+			return fmt.Errorf("error while getting raw row iterator for %s: %w", filepath.Base(file), err)
+		}
+		defer rawRows.Close()
+	}
+
+	segmenter := newItemSegmenter(itemBoundariesFrom(opts...), recordTypeIndex)
+	warnings, _ := reportWarningsFrom(opts...)
+	_, checkGTINs := flagInvalidGTINsFrom(opts...)
+	itemIDs := make(map[string]int)
 
 	var currentRow int = 0
-	var cells []string
-	var emptyRows int = 0
 	var parseItem bool = false
-	var itemCacheRow int
-	var itemCache [][]string
+	var excluded bool = false
+
+	send := func(item *itemSegment) error {
+		if item == nil || !parseItem || excluded {
+			return nil
+		}
+
+		t := parseTarget{file: file, beginningRow: item.beginningRow, rowContents: item.rows, rawContents: item.rawRows}
+
+		if done != nil {
+			select {
+			case parseBuffer <- t:
+				return nil
+			case <-done:
+				return errReadCancelled
+			}
+		}
+
+		select {
+		case parseBuffer <- t:
+		case <-time.After(parseBufferSendTimeout):
+			return &Error{Kind: ErrTimeout, File: file, SpecID: locate.ID, Err: fmt.Errorf("reader timed out on row %d while waiting to send to parse buffer", currentRow)}
+		}
+
+		return nil
+	}
 
 	for rows.Next() {
 		currentRow++
 
-		cells, err = rows.Columns()
+		cells, err := rows.Columns()
 		if err != nil {
 			return fmt.Errorf("error while reading row %d in %s: %w", currentRow, filepath.Base(file), err)
 		}
 
-		if keyHeaderIndex > len(cells) || recordTypeIndex > len(cells) {
-			emptyRows++
-			continue
-		} else if cells[keyHeaderIndex] == "" {
-			emptyRows++
-		} else {
-			emptyRows = 0
+		var raw []string
+		if rawRows != nil && rawRows.Next() {
+			if raw, err = rawRows.Columns(excelize.Options{RawCellValue: true}); err != nil {
+				return fmt.Errorf("error while reading raw row %d in %s: %w", currentRow, filepath.Base(file), err)
+			}
 		}
 
-		if emptyRows > emptyRowMax {
-			break
+		if currentRow%rowsReadInterval == 0 {
+			progress.rowsRead(file, currentRow)
 		}
 
-		if cells[recordTypeIndex] == itemRecordType {
-			if parseItem {
-				parseItem = false
+		if currentRow <= headerRowCache[file] {
+			continue
+		}
 
-				t := parseTarget{}
-				t.file = file
-				t.beginningRow = currentRow - len(itemCache[:itemCacheRow+1])
-				t.rowContents = append(t.rowContents, itemCache[:itemCacheRow+1]...)
+		completed, stop := segmenter.push(currentRow, cells, raw)
+		if completed != nil {
+			if err := send(completed); err != nil {
+				return err
+			}
 
-				select {
-				case parseBuffer <- t:
-				case <-time.After(parseBufferSendTimeout):
-					return fmt.Errorf("reader for %s timed out on row %d while waiting to send to parse buffer", filepath.Base(file), currentRow)
-				}
+			parseItem = false
+		}
+
+		if stop {
+			warnings.warn(Warning{Kind: WarningEarlyTermination, File: file, Row: currentRow, Message: fmt.Sprintf("reading stopped after %d consecutive blank rows", segmenter.blankRows)})
+			break
+		} else if !segmenter.inItem() {
+			if !isBlankRow(cells) {
+				warnings.warn(Warning{Kind: WarningRowBeforeFirstItem, File: file, Row: currentRow, Message: "row is not part of any item"})
 			}
 
-			itemCacheRow = 0
-		} else {
-			itemCacheRow++
+			continue
 		}
 
-		if locate.Field.Matches(cells[keyHeaderIndex]) {
-			locate.Field.matchCount++
-			if locate.Field.matchCount >= locate.Field.OnMatch {
-				parseItem = true
+		if segmenter.current.beginningRow == currentRow {
+			warnDuplicateItemID(warnings, itemIDs, file, currentRow, itemIDIndex, cells)
+
+			if checkGTINs {
+				warnInvalidGTIN(warnings, file, currentRow, itemIDIndex, itemTypeIndex, cells)
 			}
+
+			excluded = !filter.admits(cells)
 		}
 
-		if itemCacheRow >= len(itemCache) {
-			itemCache = append(itemCache, cells)
-		} else {
-			itemCache[itemCacheRow] = cells
+		if excluded {
+			continue
 		}
 
+		if !isBlankRow(cells) && len(cells) <= keyHeaderIndices[len(keyHeaderIndices)-1] {
+			a, _ := excelize.CoordinatesToCellName(keyHeaderIndices[len(keyHeaderIndices)-1]+1, currentRow)
+			warnings.warn(Warning{Kind: WarningRaggedRow, File: file, Row: currentRow, Cell: a, Message: fmt.Sprintf("row has %d cells, ending before the key column of spec %s", len(cells), locate.ID)})
+		}
+
+		for _, keyHeaderIndex := range keyHeaderIndices {
+			if locate.Field.Matches(cellAt(cells, keyHeaderIndex)) {
+				locate.Field.matchCount++
+				if locate.Field.matchCount >= locate.Field.OnMatch {
+					parseItem = true
+				}
+			}
+		}
 	}
-	return nil
+
+	progress.rowsRead(file, currentRow)
+
+	return send(segmenter.flush())
+}
+
+// warnDuplicateItemID sends a warning if the item ID within the given first row of an item has already been seen,
+// recording its row otherwise.
+func warnDuplicateItemID(warnings optionReportWarnings, seen map[string]int, file string, row int, itemIDIndex int, cells []string) {
+	id := cellAt(cells, itemIDIndex)
+	if id == "" {
+		return
+	}
+
+	first, exist := seen[id]
+	if !exist {
+		seen[id] = row
+		return
+	}
+
+	a, _ := excelize.CoordinatesToCellName(itemIDIndex+1, row)
+	warnings.warn(Warning{Kind: WarningDuplicateItemID, File: file, Row: row, Cell: a, Message: fmt.Sprintf("item ID %s was first seen on row %d", id, first)})
 }
@@ -0,0 +1,196 @@
+package fusereader
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Kindred87/fusereader/gtin"
+	"github.com/xuri/excelize/v2"
+)
+
+// ConflictKind describes the category of a ReplayConflict.
+type ConflictKind int
+
+const (
+	ConflictAddExisting      ConflictKind = iota // ConflictAddExisting indicates an ADD of an item already in the catalog.  The item is replaced.
+	ConflictChangeMissing                        // ConflictChangeMissing indicates a CHANGE or CORRECT of an item absent from the catalog.  The item is added.
+	ConflictDeleteMissing                        // ConflictDeleteMissing indicates a DELETE of an item absent from the catalog.  The operation is ignored.
+	ConflictUnknownOperation                     // ConflictUnknownOperation indicates an item with an unrecognised OPERATION.  The operation is ignored.
+)
+
+// String returns the name of the conflict kind.
+func (k ConflictKind) String() string {
+	switch k {
+	case ConflictAddExisting:
+		return "add of existing item"
+	case ConflictChangeMissing:
+		return "change of missing item"
+	case ConflictDeleteMissing:
+		return "delete of missing item"
+	case ConflictUnknownOperation:
+		return "unknown operation"
+	}
+
+	return fmt.Sprintf("conflict kind %d", int(k))
+}
+
+// ReplayConflict describes an operation that could not be applied cleanly to the catalog, typically because the files
+// were sent or replayed out of order.
+type ReplayConflict struct {
+	Kind      ConflictKind `json:"kind"`      // Kind is the category of the conflict.
+	ItemID    string       `json:"itemID"`    // ItemID is the Item ID of the item.
+	Operation Operation    `json:"operation"` // Operation is the operation that could not be applied cleanly.
+	File      string       `json:"file"`      // File is the path of the file containing the operation.
+	Row       int          `json:"row"`       // Row is the one-based row number of the item's first row.
+}
+
+// String returns a description of the conflict and its location.
+func (c ReplayConflict) String() string {
+	return fmt.Sprintf("%s at %s row %d: %s %s", c.Kind, filepath.Base(c.File), c.Row, c.Operation, c.ItemID)
+}
+
+// Catalog is the current state of items after applying a series of FUSE files.
+type Catalog struct {
+	Items     []Item           `json:"items"`     // Items contains the current state of each item, ordered by Item ID.
+	Conflicts []ReplayConflict `json:"conflicts"` // Conflicts contains the operations that could not be applied cleanly, in the order encountered.
+}
+
+// Replay applies the items within the given files, in the order given, to produce the current state of each item.
+//
+// ADD creates an item, CHANGE and CORRECT replace it, and DELETE removes it.  Items are identified by Item ID, with
+// GTINs compared regardless of form.  Operations that do not fit the catalog's state at the time are still applied
+// where possible and are reported as conflicts.
+func Replay(files []string, opts ...Option) (Catalog, error) {
+	current := make(map[string]Item)
+	conflicts := []ReplayConflict{}
+
+	err := readItems(files, func(it Item) error {
+		key := itemKey(it.ID)
+		_, exist := current[key]
+
+		conflict := ReplayConflict{ItemID: it.ID, Operation: it.Operation, File: it.File, Row: it.BeginningRow}
+
+		switch it.Operation {
+		case OperationAdd:
+			if exist {
+				conflict.Kind = ConflictAddExisting
+				conflicts = append(conflicts, conflict)
+			}
+
+			current[key] = it
+		case OperationChange, OperationCorrect:
+			if !exist {
+				conflict.Kind = ConflictChangeMissing
+				conflicts = append(conflicts, conflict)
+			}
+
+			current[key] = it
+		case OperationDelete:
+			if !exist {
+				conflict.Kind = ConflictDeleteMissing
+				conflicts = append(conflicts, conflict)
+			}
+
+			delete(current, key)
+		default:
+			conflict.Kind = ConflictUnknownOperation
+			conflicts = append(conflicts, conflict)
+		}
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return Catalog{}, fmt.Errorf("error while replaying items: %w", err)
+	}
+
+	catalog := Catalog{Items: make([]Item, 0, len(current)), Conflicts: conflicts}
+	for _, it := range current {
+		catalog.Items = append(catalog.Items, it)
+	}
+
+	sort.Slice(catalog.Items, func(i, j int) bool { return catalog.Items[i].ID < catalog.Items[j].ID })
+
+	return catalog, nil
+}
+
+// Item returns the current state of the item with the given Item ID, with GTINs compared regardless of form.
+func (c Catalog) Item(id string) (Item, bool) {
+	key := itemKey(id)
+
+	for _, it := range c.Items {
+		if itemKey(it.ID) == key {
+			return it, true
+		}
+	}
+
+	return Item{}, false
+}
+
+// JSON returns the catalog encoded as indented JSON.
+func (c Catalog) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// WriteCSV writes the catalog's items to the given writer as CSV, with a record per non-empty cell.
+//
+// Each record contains the Item ID, operation, file, cell address, header and value.
+func (c Catalog) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"Item ID", "Operation", "File", "Cell", "Header", "Value"}); err != nil {
+		return fmt.Errorf("error while writing CSV header: %w", err)
+	}
+
+	for _, it := range c.Items {
+		for i, row := range it.Rows {
+			for col, v := range row {
+				if v == "" {
+					continue
+				}
+
+				a, err := excelize.CoordinatesToCellName(col+1, it.BeginningRow+i)
+				if err != nil {
+					return fmt.Errorf("error while converting column %d and row %d to a cell name: %w", col, it.BeginningRow+i, err)
+				}
+
+				header := ""
+				if col < len(it.Headers) {
+					header = it.Headers[col]
+				}
+
+				if err := cw.Write([]string{it.ID, string(it.Operation), it.File, a, header, v}); err != nil {
+					return fmt.Errorf("error while writing CSV record for %s: %w", it.ID, err)
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// String returns a human-readable summary of the catalog and its conflicts.
+func (c Catalog) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d items, %d conflicts\n", len(c.Items), len(c.Conflicts))
+	for _, conflict := range c.Conflicts {
+		fmt.Fprintf(&b, "  %s\n", conflict)
+	}
+
+	return b.String()
+}
+
+// itemKey returns the key identifying the item with the given Item ID, normalising GTINs to 14 digits.
+func itemKey(id string) string {
+	if n, err := gtin.Normalize(id); err == nil {
+		return n
+	}
+
+	return strings.TrimSpace(id)
+}
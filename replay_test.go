@@ -0,0 +1,70 @@
+package fusereader
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay(t *testing.T) {
+	header := groupedHeaderRow(1)
+	week1 := newTestFile(t, [][]string{
+		header,
+		{itemRecordType, "ADD", "Y", "0614141000012", "Acme", "GTIN", "00011110603081", "", "SOYBEANS -- Soybeans", "FREE_FROM -- Free from"},
+		{itemRecordType, "ADD", "Y", "0614141000012", "Acme", "GTIN", "00077661003169", "", "MILK -- Milk", "CONTAINS -- Contains"},
+	})
+	week2 := newTestFile(t, [][]string{
+		header,
+		{itemRecordType, "CHANGE", "Y", "0614141000012", "Acme", "GTIN", "011110603081", "", "SOYBEANS -- Soybeans", "CONTAINS -- Contains"},
+		{itemRecordType, "DELETE", "Y", "0614141000012", "Acme", "GTIN", "00077661003169"},
+		{itemRecordType, "DELETE", "Y", "0614141000029", "Globex", "GTIN", "10011110603088"},
+		{itemRecordType, "CHANGE", "Y", "0614141000029", "Globex", "GTIN", "10011110603088", "", "WHEAT -- Wheat", "CONTAINS -- Contains"},
+		{itemRecordType, "ADD", "Y", "0614141000012", "Acme", "GTIN", "00011110603081", "", "SOYBEANS -- Soybeans", "MAY_CONTAIN -- May contain"},
+		{itemRecordType, "UPSERT", "Y", "0614141000012", "Acme", "GTIN", "00011110603081"},
+	})
+
+	got, err := Replay([]string{week1, week2})
+	require.Nil(t, err)
+
+	if assert.Len(t, got.Items, 2) {
+		assert.Equal(t, "00011110603081", got.Items[0].ID)
+		assert.Equal(t, "MAY_CONTAIN", got.Items[0].CodeValue("Level Of Containment").Code)
+		assert.Equal(t, "10011110603088", got.Items[1].ID)
+	}
+
+	_, exist := got.Item("077661003169")
+	assert.False(t, exist)
+
+	it, exist := got.Item("011110603081")
+	assert.True(t, exist)
+	assert.Equal(t, OperationAdd, it.Operation)
+
+	var kinds []ConflictKind
+	for _, c := range got.Conflicts {
+		kinds = append(kinds, c.Kind)
+	}
+	assert.Equal(t, []ConflictKind{ConflictDeleteMissing, ConflictChangeMissing, ConflictAddExisting, ConflictUnknownOperation}, kinds)
+	assert.Equal(t, 4, got.Conflicts[0].Row)
+
+	var buf bytes.Buffer
+	require.Nil(t, got.WriteCSV(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Item ID", "Operation", "File", "Cell", "Header", "Value"}, records[0])
+	assert.Contains(t, records, []string{"00011110603081", "ADD", week2, "J6", "Level Of Containment", "MAY_CONTAIN -- May contain"})
+
+	_, err = got.JSON()
+	assert.Nil(t, err)
+
+	got, err = Replay([]string{week1}, FilterProviders("0614141000036"))
+	require.Nil(t, err)
+
+	b, err := got.JSON()
+	require.Nil(t, err)
+	assert.Contains(t, string(b), `"items": []`)
+	assert.Contains(t, string(b), `"conflicts": []`)
+}
@@ -0,0 +1,131 @@
+package fusereader
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// RunReport records the outcome of each file in a run performed with ContinueOnFileError.
+type RunReport struct {
+	Succeeded []string         // Succeeded contains the files that were processed without error.
+	Failed    []string         // Failed contains the files that could not be opened or read.
+	Skipped   []string         // Skipped contains the files that were opened, but whose header row or headers did not satisfy the given specifications.
+	Errors    map[string]error // Errors maps each failed or skipped file to the reason.
+	mu        sync.Mutex       // mu guards the report against concurrent updates from workers.
+}
+
+// Summary returns a one-line summary of the number of files that succeeded, failed, or were skipped.
+func (r *RunReport) Summary() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return fmt.Sprintf("%d succeeded, %d failed, %d skipped", len(r.Succeeded), len(r.Failed), len(r.Skipped))
+}
+
+// fail records that the given file could not be opened or read.  Only the first failure of a file is recorded.
+func (r *RunReport) fail(file string, err error) {
+	r.record(file, err, &r.Failed)
+}
+
+// skip records that the given file did not satisfy the given specifications.
+func (r *RunReport) skip(file string, err error) {
+	r.record(file, err, &r.Skipped)
+}
+
+// record adds the given file to the given list and its error to the error map, unless an error has already been
+// recorded for the file.
+func (r *RunReport) record(file string, err error, list *[]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Errors == nil {
+		r.Errors = make(map[string]error)
+	}
+
+	if _, exist := r.Errors[file]; exist {
+		return
+	}
+
+	r.Errors[file] = err
+	*list = append(*list, file)
+}
+
+// succeed records each of the given files for which no error has been recorded as having succeeded.
+func (r *RunReport) succeed(files []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, file := range files {
+		if _, exist := r.Errors[file]; !exist {
+			r.Succeeded = append(r.Succeeded, file)
+		}
+	}
+}
+
+// remaining returns the given files for which no error has been recorded.
+func (r *RunReport) remaining(files []string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []string
+
+	for _, file := range files {
+		if _, exist := r.Errors[file]; !exist {
+			out = append(out, file)
+		}
+	}
+
+	return out
+}
+
+// buildCachesTolerantly builds the file and header caches using the given files, recording files that cannot be
+// opened or whose header rows cannot be found in the given report.  The files that were cached are returned.
+func buildCachesTolerantly(files []string, report *RunReport, opts ...Option) ([]string, error) {
+	headerRule = headerRowRuleFrom(opts...)
+
+	var cached []*excelize.File
+
+	for _, path := range files {
+		if err := cacheFile(path); err != nil {
+			report.fail(path, err)
+			continue
+		}
+
+		fi, err := getFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error while getting file pointer for %s: %w", filepath.Base(path), err)
+		}
+
+		if _, err := headersFrom(fi); err != nil {
+			report.skip(path, err)
+			continue
+		}
+
+		cached = append(cached, fi)
+	}
+
+	if len(cached) == 0 {
+		return nil, nil
+	}
+
+	if err := buildHeaderCaches(cached...); err != nil {
+		return nil, fmt.Errorf("error while loading headers: %w", err)
+	}
+
+	return report.remaining(files), nil
+}
+
+// validateParametersTolerantly records each of the given files in which the given specifications cannot be resolved
+// as skipped in the given report.  The remaining files are returned.
+func validateParametersTolerantly(files []string, locate []FieldLocation, retrieve []FieldRetrieval, report *RunReport) []string {
+	for _, file := range files {
+		if err := validateParametersForSearching([]string{file}, locate, retrieve); err != nil {
+			report.skip(file, err)
+		}
+	}
+
+	return report.remaining(files)
+}
@@ -0,0 +1,38 @@
+package fusereader
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFieldsContinueOnFileError(t *testing.T) {
+	valid := newTestFile(t, itemTestRows())
+	noHeaderRow := newTestFile(t, [][]string{{"Banner"}})
+	noAllergens := newTestFile(t, [][]string{headerRowPrefix(), {itemRecordType, "ADD", "Y", "", "", "GTIN", "00011110603081"}})
+	files := []string{valid, "bad_file.xlsx", noHeaderRow, noAllergens}
+
+	report := &RunReport{}
+
+	c := make(chan field, 10)
+	err := GetFields(files, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, c, ContinueOnFileError(report))
+	require.Nil(t, err)
+	close(c)
+
+	assert.Len(t, collectFields(c), 1)
+
+	assert.Equal(t, []string{valid}, report.Succeeded)
+	assert.Equal(t, []string{"bad_file.xlsx"}, report.Failed)
+	assert.ElementsMatch(t, []string{noHeaderRow, noAllergens}, report.Skipped)
+	assert.Equal(t, "1 succeeded, 1 failed, 2 skipped", report.Summary())
+
+	assert.True(t, errors.Is(report.Errors["bad_file.xlsx"], ErrFileOpen))
+	assert.True(t, errors.Is(report.Errors[noHeaderRow], ErrHeaderRowNotFound))
+	assert.True(t, errors.Is(report.Errors[noAllergens], ErrHeaderNotFound))
+	assert.NotContains(t, report.Errors, valid)
+
+	err = GetFields(files, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, make(chan field, 10), ContinueOnFileError(nil))
+	assert.NotNil(t, err)
+}
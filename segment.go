@@ -0,0 +1,138 @@
+package fusereader
+
+// ItemBoundaries describes how worksheet rows are divided into items.
+//
+// An item begins with a row whose RECORD TYPE is one of RecordTypes and continues until the next such row.  Rows
+// preceding the first item are not part of any item.
+type ItemBoundaries struct {
+	RecordTypes   []string // RecordTypes contains the RECORD TYPE values that begin an item.  If empty, only ITEM begins an item.
+	BlankRowLimit int      // BlankRowLimit is the number of consecutive blank rows after which segmentation stops.  A value of zero results in a limit of 50, while a negative value disables the limit.
+}
+
+// itemBoundariesFrom returns the item boundaries described by the given options.
+func itemBoundariesFrom(opts ...Option) ItemBoundaries {
+	if o, ok := segmentItemsFrom(opts...); ok {
+		return o.boundaries
+	}
+
+	return ItemBoundaries{}
+}
+
+// beginsItem returns true if the given record type begins an item.
+func (b ItemBoundaries) beginsItem(recordType string) bool {
+	if len(b.RecordTypes) == 0 {
+		return recordType == itemRecordType
+	}
+
+	for _, t := range b.RecordTypes {
+		if recordType == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// blankRowLimit returns the number of consecutive blank rows after which segmentation stops, or a negative value if
+// there is no limit.
+func (b ItemBoundaries) blankRowLimit() int {
+	if b.BlankRowLimit == 0 {
+		return emptyRowMax
+	}
+
+	return b.BlankRowLimit
+}
+
+// itemSegment contains the rows of a single item.
+type itemSegment struct {
+	beginningRow int        // beginningRow is the one-based row number of the item's first row.
+	rows         [][]string // rows contains the contents of the item's rows.
+	rawRows      [][]string // rawRows contains the contents of the item's rows without number formats applied, if read.
+}
+
+// itemSegmenter divides a sequence of worksheet rows into items.
+type itemSegmenter struct {
+	boundaries      ItemBoundaries // boundaries describes where items begin and when segmentation stops.
+	recordTypeIndex int            // recordTypeIndex is the zero-based index of the RECORD TYPE column.
+	current         *itemSegment   // current is the item currently being assembled, if any.
+	blankRows       int            // blankRows is the number of consecutive blank rows most recently pushed.
+	stopped         bool           // stopped is true once the blank row limit has been exceeded.
+}
+
+// newItemSegmenter returns a segmenter dividing rows using the given boundaries and RECORD TYPE column index.
+func newItemSegmenter(boundaries ItemBoundaries, recordTypeIndex int) *itemSegmenter {
+	return &itemSegmenter{boundaries: boundaries, recordTypeIndex: recordTypeIndex}
+}
+
+// push adds the given one-based row to the segmenter, along with its raw contents if they were read.
+//
+// If the row begins an item, the previous item is complete and is returned.  If the blank row limit has been exceeded,
+// stop will be true and the row is discarded; flush should then be called to retrieve the final item.
+func (s *itemSegmenter) push(rowNum int, cells, raw []string) (completed *itemSegment, stop bool) {
+	if s.stopped {
+		return nil, true
+	}
+
+	if isBlankRow(cells) {
+		s.blankRows++
+
+		if limit := s.boundaries.blankRowLimit(); limit >= 0 && s.blankRows > limit {
+			s.stopped = true
+			return nil, true
+		}
+	} else {
+		s.blankRows = 0
+	}
+
+	if s.boundaries.beginsItem(cellAt(cells, s.recordTypeIndex)) {
+		completed = s.current.trimmed()
+		s.current = &itemSegment{beginningRow: rowNum}
+	}
+
+	if s.current == nil {
+		return completed, false
+	}
+
+	s.current.rows = append(s.current.rows, cells)
+	s.current.rawRows = append(s.current.rawRows, raw)
+
+	return completed, false
+}
+
+// inItem returns true if the most recently pushed row belongs to an item.
+func (s *itemSegmenter) inItem() bool {
+	return s.current != nil && !s.stopped
+}
+
+// flush returns the item currently being assembled, if any, with trailing blank rows removed.
+func (s *itemSegmenter) flush() *itemSegment {
+	out := s.current
+	s.current = nil
+
+	return out.trimmed()
+}
+
+// trimmed returns the item with trailing blank rows removed.
+func (i *itemSegment) trimmed() *itemSegment {
+	if i == nil {
+		return nil
+	}
+
+	for len(i.rows) > 1 && isBlankRow(i.rows[len(i.rows)-1]) {
+		i.rows = i.rows[:len(i.rows)-1]
+		i.rawRows = i.rawRows[:len(i.rawRows)-1]
+	}
+
+	return i
+}
+
+// isBlankRow returns true if every cell in the given row is empty.
+func isBlankRow(cells []string) bool {
+	for _, c := range cells {
+		if c != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,120 @@
+package fusereader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// segmentAll pushes the given rows, numbered from one, through a new segmenter and returns every item produced.
+func segmentAll(boundaries ItemBoundaries, rows [][]string) ([]*itemSegment, []int) {
+	s := newItemSegmenter(boundaries, 0)
+
+	var out []*itemSegment
+	var preItem []int
+
+	for i, row := range rows {
+		completed, stop := s.push(i+1, row, nil)
+		if completed != nil {
+			out = append(out, completed)
+		}
+
+		if stop {
+			break
+		} else if !s.inItem() && !isBlankRow(row) {
+			preItem = append(preItem, i+1)
+		}
+	}
+
+	if item := s.flush(); item != nil {
+		out = append(out, item)
+	}
+
+	return out, preItem
+}
+
+func Test_itemSegmenter(t *testing.T) {
+	tests := []struct {
+		name          string
+		boundaries    ItemBoundaries
+		rows          [][]string
+		wantBeginning []int
+		wantLengths   []int
+		wantPreItem   []int
+	}{
+		{
+			name:          "Trailing item",
+			rows:          [][]string{{"ITEM", "a"}, {"", "b"}, {"ITEM", "c"}},
+			wantBeginning: []int{1, 3},
+			wantLengths:   []int{2, 1},
+		},
+		{
+			name:          "Rows before first item",
+			rows:          [][]string{{"NOTE", "x"}, {}, {"", "y"}, {"ITEM", "a"}},
+			wantBeginning: []int{4},
+			wantLengths:   []int{1},
+			wantPreItem:   []int{1, 3},
+		},
+		{
+			name:          "Custom record types",
+			boundaries:    ItemBoundaries{RecordTypes: []string{"ITEM", "PACK"}},
+			rows:          [][]string{{"ITEM", "a"}, {"PACK", "b"}, {"NOTE", "c"}},
+			wantBeginning: []int{1, 2},
+			wantLengths:   []int{1, 2},
+		},
+		{
+			name:          "Blank row limit",
+			boundaries:    ItemBoundaries{BlankRowLimit: 2},
+			rows:          [][]string{{"ITEM", "a"}, {}, {}, {}, {"ITEM", "b"}},
+			wantBeginning: []int{1},
+			wantLengths:   []int{1},
+		},
+		{
+			name:          "Blank rows within limit",
+			boundaries:    ItemBoundaries{BlankRowLimit: 2},
+			rows:          [][]string{{"ITEM", "a"}, {}, {}, {"", "b"}, {}},
+			wantBeginning: []int{1},
+			wantLengths:   []int{4},
+		},
+		{
+			name:          "Blank row limit disabled",
+			boundaries:    ItemBoundaries{BlankRowLimit: -1},
+			rows:          append(append([][]string{{"ITEM", "a"}}, make([][]string, 100)...), []string{"ITEM", "b"}),
+			wantBeginning: []int{1, 102},
+			wantLengths:   []int{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, preItem := segmentAll(tt.boundaries, tt.rows)
+			require.Len(t, got, len(tt.wantBeginning))
+
+			for i, item := range got {
+				assert.Equal(t, tt.wantBeginning[i], item.beginningRow)
+				assert.Len(t, item.rows, tt.wantLengths[i])
+			}
+
+			assert.Equal(t, tt.wantPreItem, preItem)
+		})
+	}
+}
+
+func TestGetFieldsForFinalItem(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	locate := validFieldLocation()
+	locate.Field.Matches = func(s string) bool { return s == "00077661003169" }
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{locate}, []FieldRetrieval{validRetrieveSpec()}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "00077661003169", got[0].ItemID())
+		assert.Equal(t, "CONTAINS -- Contains", got[0].Value())
+		assert.Equal(t, "J5", got[0].Address())
+	}
+}
@@ -1,5 +1,12 @@
 package fusereader
 
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/xuri/excelize/v2"
+)
+
 // FieldLocation provides a specification for a field value that is used to identify an item of interest.
 type FieldLocation struct {
 	ID     string              // ID uniquely identifies a FieldSpecification instance.
@@ -8,10 +15,77 @@ type FieldLocation struct {
 }
 
 // HeaderSpecification provides a specification for a specific header within a given file.
+//
+// A header may be identified by Key and OthersInGroup, by its text using Pattern or MatchHeader, or directly by Column
+// or ColumnIndex.
 type HeaderSpecification struct {
-	Key           string   // Key is the key header of the specification, directly under which fields will be searched.
-	OthersInGroup []string // OthersInGroup contains other headers in the same group as the key header.  These are used to distinguish between key headers contained in multiple different groups.
-	OnMatch       int      // OnMatch describes which identified header should be referenced, if there are multiple identified.  A value less than or equal to one will result in the first identified header being referenced, a value of two the second identified header, and so on.  This value is most useful for key headers that are in multiple header groups containing the same sets of headers.
+	Key           string            // Key is the key header of the specification, directly under which fields will be searched.
+	OthersInGroup []string          // OthersInGroup contains other headers in the same group as the key header.  These are used to distinguish between key headers contained in multiple different groups.
+	OnMatch       int               // OnMatch describes which identified header should be referenced, if there are multiple identified.  A value less than or equal to one will result in the first identified header being referenced, a value of two the second identified header, and so on.  This value is most useful for key headers that are in multiple header groups containing the same sets of headers.
+	Order         []HeaderOrder     // Order contains constraints on the positions of headers within the group.  Only groups satisfying every constraint are considered.
+	Column        string            // Column is the letter of the header's column, such as "AY".  If set, Key, OthersInGroup, OnMatch, and Order are ignored.
+	ColumnIndex   *int              // ColumnIndex is the zero-based index of the header's column.  If set, Key, OthersInGroup, OnMatch, and Order are ignored.
+	Pattern       *regexp.Regexp    // Pattern selects headers whose normalised text it matches, ignoring case if case is folded.  If set, Key, OthersInGroup, and Order are ignored and OnMatch refers to the Nth selected header.
+	MatchHeader   func(string) bool // MatchHeader selects headers for which it returns true when given their normalised text.  If set, Key, OthersInGroup, and Order are ignored and OnMatch refers to the Nth selected header.  If Pattern is also set, both must select a header.
+	FanOut        bool              // FanOut references every identified header, rather than the one described by OnMatch.
+}
+
+// selectsByText returns true if the specification identifies its headers using Pattern or MatchHeader.
+func (h HeaderSpecification) selectsByText() bool {
+	return h.Pattern != nil || h.MatchHeader != nil
+}
+
+// selects returns true if the given header text is selected by the specification's Pattern and MatchHeader.
+func (h HeaderSpecification) selects(header string) bool {
+	if h.Pattern != nil && !h.Pattern.MatchString(header) {
+		return false
+	}
+
+	return h.MatchHeader == nil || h.MatchHeader(header)
+}
+
+// addressed returns true if the specification identifies its header by column rather than by key.
+func (h HeaderSpecification) addressed() bool {
+	return h.Column != "" || h.ColumnIndex != nil
+}
+
+// columnIndex returns the zero-based column index addressed by the specification.
+func (h HeaderSpecification) columnIndex() (int, error) {
+	if h.ColumnIndex != nil {
+		if *h.ColumnIndex < 0 {
+			return 0, fmt.Errorf("column index %d is negative", *h.ColumnIndex)
+		}
+
+		return *h.ColumnIndex, nil
+	}
+
+	n, err := excelize.ColumnNameToNumber(h.Column)
+	if err != nil {
+		return 0, fmt.Errorf("error while converting column %s to an index: %w", h.Column, err)
+	}
+
+	return n - 1, nil
+}
+
+// describe returns a short description of the header identified by the specification, for use in messages.
+func (h HeaderSpecification) describe() string {
+	if h.ColumnIndex != nil {
+		return fmt.Sprintf("column index %d", *h.ColumnIndex)
+	} else if h.Column != "" {
+		return fmt.Sprintf("column %s", h.Column)
+	} else if h.Pattern != nil {
+		return fmt.Sprintf("headers matching %s", h.Pattern)
+	} else if h.MatchHeader != nil {
+		return "headers selected by predicate"
+	}
+
+	return h.Key
+}
+
+// HeaderOrder constrains the relative positions of two headers within a header group.
+type HeaderOrder struct {
+	Before string // Before is the header that must precede After within the group.
+	After  string // After is the header that must follow Before within the group.
 }
 
 // FieldSpecification provides a specification for identifying a field of interest.
@@ -27,6 +101,7 @@ type FieldRetrieval struct {
 	Header       HeaderSpecification // Header contains the header specification.
 	Field        FieldSpecification  // Spec identifies a field from which offset fields will be retrieved.
 	FieldOffsets []int               // RetrievalOffsets is a slice of right-facing offsets from the field described by Spec.  Fields at the offsets will be retrieved.
+	UnitHeader   string              // UnitHeader optionally names the unit of measure header within the group of each retrieved field.  If set, each retrieved field carries the unit alongside its value, as returned by Measurement.
 }
 
 // NewFieldLocationAll returns a field location object containing the given fields and sub-fields.
@@ -51,7 +126,7 @@ func NewFieldLocationAll(id, keyHeader string, otherHeaders []string, headerOnMa
 //
 // For more information on field location fields, please see FieldLocation, HeaderSpecification, and FieldSpecification.
 func NewFieldLocationShort(id string, header HeaderSpecification, field FieldSpecification) FieldLocation {
-	return NewFieldLocationAll(id, header.Key, header.OthersInGroup, header.OnMatch, field.Matches, field.OnMatch)
+	return FieldLocation{ID: id, Header: header, Field: NewFieldSpecification(field.Matches, field.OnMatch)}
 }
 
 // NewHeaderSpecification returns a header specification object containing the given fields.
@@ -63,6 +138,24 @@ func NewHeaderSpecification(key string, othersInGroup []string, onMatch int) Hea
 	}
 }
 
+// NewHeaderSpecificationAtColumn returns a header specification identifying the header in the column with the given
+// letter, such as "AY".
+func NewHeaderSpecificationAtColumn(column string) HeaderSpecification {
+	return HeaderSpecification{Column: column}
+}
+
+// NewHeaderSpecificationAtIndex returns a header specification identifying the header in the column with the given
+// zero-based index.
+func NewHeaderSpecificationAtIndex(index int) HeaderSpecification {
+	return HeaderSpecification{ColumnIndex: &index}
+}
+
+// NewHeaderSpecificationMatching returns a header specification identifying headers whose text matches the given
+// pattern.  If fanOut is true, every matching header is referenced.
+func NewHeaderSpecificationMatching(pattern *regexp.Regexp, fanOut bool) HeaderSpecification {
+	return HeaderSpecification{Pattern: pattern, FanOut: fanOut}
+}
+
 // NewFieldSpecification returns a field specification object containing the given fields.
 func NewFieldSpecification(matches func(string) bool, onMatch int) FieldSpecification {
 	return FieldSpecification{
@@ -92,5 +185,5 @@ func NewFieldRetrievalAll(id, keyHeader string, otherHeaders []string, headerOnM
 
 // NewFieldRetrievalShort returns a field retrieval object containing the given fields.
 func NewFieldRetrievalShort(id string, header HeaderSpecification, field FieldSpecification, fieldOffsets []int) FieldRetrieval {
-	return NewFieldRetrievalAll(id, header.Key, header.OthersInGroup, header.OnMatch, field.Matches, field.OnMatch, fieldOffsets)
+	return FieldRetrieval{ID: id, Header: header, Field: NewFieldSpecification(field.Matches, field.OnMatch), FieldOffsets: fieldOffsets}
 }
@@ -0,0 +1,137 @@
+package fusereader
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// codeDescriptionSeparator separates a code from its description within FUSE code-list values.
+const codeDescriptionSeparator = " -- "
+
+// timeLayouts contains the ISO 8601 layouts accepted by Time, in order of preference.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Int returns the contents of the field as an integer.
+//
+// The raw cell value is preferred over the formatted value, such that number formats like thousands separators do not
+// interfere.
+func (f field) Int() (int, error) {
+	s := f.numericText()
+
+	if i, err := strconv.Atoi(s); err == nil {
+		return i, nil
+	}
+
+	if d, err := strconv.ParseFloat(s, 64); err == nil && d == math.Trunc(d) && math.Abs(d) < 1<<53 {
+		return int(d), nil
+	}
+
+	return 0, f.parseError("an integer")
+}
+
+// Decimal returns the contents of the field as a decimal number.
+//
+// The raw cell value is preferred over the formatted value, such that number formats like thousands separators do not
+// interfere.
+func (f field) Decimal() (float64, error) {
+	d, err := strconv.ParseFloat(f.numericText(), 64)
+	if err != nil {
+		return 0, f.parseError("a decimal number")
+	}
+
+	return d, nil
+}
+
+// Bool returns the contents of the field as a boolean.
+//
+// TRUE, Y, YES and 1 are true, while FALSE, N, NO and 0 are false, regardless of case.
+func (f field) Bool() (bool, error) {
+	switch strings.ToUpper(strings.TrimSpace(f.value)) {
+	case "TRUE", "Y", "YES", "1":
+		return true, nil
+	case "FALSE", "N", "NO", "0":
+		return false, nil
+	}
+
+	return false, f.parseError("a boolean")
+}
+
+// Time returns the contents of the field as a time.
+//
+// ISO 8601 dates and times are accepted, as are Excel serial dates for cells known to be numeric, such as those with a
+// date format.  Numeric text, such as a GTIN, is never read as a serial date.
+func (f field) Time() (time.Time, error) {
+	for _, s := range []string{strings.TrimSpace(f.value), strings.TrimSpace(f.rawValue)} {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+	}
+
+	switch f.cellType {
+	case excelize.CellTypeNumber, excelize.CellTypeDate:
+		if serial, err := strconv.ParseFloat(f.numericText(), 64); err == nil {
+			if t, err := excelize.ExcelDateToTime(serial, false); err == nil {
+				return t, nil
+			}
+		}
+	}
+
+	return time.Time{}, f.parseError("a date or time")
+}
+
+// Code returns the code within the contents of the field.
+//
+// FUSE code-list values take the form "CODE -- Description", of which only CODE is returned.  Values without a
+// description are returned in full.
+func (f field) Code() (string, error) {
+	code := ParseCodeValue(f.value).Code
+	if code == "" {
+		return "", f.parseError("a code")
+	}
+
+	return code, nil
+}
+
+// Description returns the description within the contents of the field.
+//
+// FUSE code-list values take the form "CODE -- Description", of which only Description is returned.  Values without a
+// description result in an empty string.
+func (f field) Description() string {
+	return ParseCodeValue(f.value).Description
+}
+
+// Measurement returns the contents of the field as a quantity in the unit of measure retrieved alongside it.
+func (f field) Measurement() (Measurement, error) {
+	m, err := parseMeasurement(f.numericText(), f.unit)
+	if err != nil {
+		return Measurement{}, &Error{Kind: ErrValueParse, File: f.file, SpecID: f.specID, Header: f.header, Cell: f.address, Err: err}
+	}
+
+	return m, nil
+}
+
+// numericText returns the raw cell value if present, otherwise the formatted value.
+func (f field) numericText() string {
+	if s := strings.TrimSpace(f.rawValue); s != "" {
+		return s
+	}
+
+	return strings.TrimSpace(f.value)
+}
+
+// parseError returns an ErrValueParse error stating that the field's contents are not the given description of a type.
+func (f field) parseError(target string) error {
+	return &Error{Kind: ErrValueParse, File: f.file, SpecID: f.specID, Header: f.header, Cell: f.address, Err: fmt.Errorf("%q is not %s", f.value, target)}
+}
@@ -0,0 +1,151 @@
+package fusereader
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func Test_fieldTypedAccessors(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        field
+		wantInt  int
+		wantDec  float64
+		wantBool bool
+		wantTime time.Time
+		wantCode string
+		wantErrs []bool // wantErrs contains whether Int, Decimal, Bool, Time and Code should fail, in that order.
+	}{
+		{name: "Integer", f: field{value: "12", cellType: excelize.CellTypeNumber}, wantInt: 12, wantDec: 12, wantTime: time.Date(1900, 1, 11, 0, 0, 0, 0, time.UTC), wantCode: "12", wantErrs: []bool{false, false, true, false, false}},
+		{name: "Formatted number", f: field{value: "1,234.50", rawValue: "1234.5", cellType: excelize.CellTypeNumber}, wantDec: 1234.5, wantTime: time.Date(1903, 5, 18, 12, 0, 0, 0, time.UTC), wantCode: "1,234.50", wantErrs: []bool{true, false, true, false, false}},
+		{name: "Flag", f: field{value: "Y", cellType: excelize.CellTypeString}, wantBool: true, wantCode: "Y", wantErrs: []bool{true, true, false, true, false}},
+		{name: "Boolean", f: field{value: "FALSE", rawValue: "0", cellType: excelize.CellTypeBool}, wantDec: 0, wantErrs: []bool{false, false, false, true, false}, wantCode: "FALSE"},
+		{name: "ISO date", f: field{value: "2022-06-01", cellType: excelize.CellTypeString}, wantTime: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), wantCode: "2022-06-01", wantErrs: []bool{true, true, true, false, false}},
+		{name: "Serial date", f: field{value: "06-01-22", rawValue: "44713", cellType: excelize.CellTypeNumber}, wantInt: 44713, wantDec: 44713, wantTime: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), wantCode: "06-01-22", wantErrs: []bool{false, false, true, false, false}},
+		{name: "GTIN", f: field{value: "00011110603081", rawValue: "00011110603081"}, wantInt: 11110603081, wantDec: 11110603081, wantCode: "00011110603081", wantErrs: []bool{false, false, true, true, false}},
+		{name: "Code", f: field{value: "FREE_FROM -- Free from", cellType: excelize.CellTypeString}, wantCode: "FREE_FROM", wantErrs: []bool{true, true, true, true, false}},
+		{name: "Empty", f: field{}, wantErrs: []bool{true, true, true, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, err := tt.f.Int()
+			if assert.Equal(t, tt.wantErrs[0], err != nil, "Int: %v", err) && err == nil {
+				assert.Equal(t, tt.wantInt, i)
+			}
+
+			d, err := tt.f.Decimal()
+			if assert.Equal(t, tt.wantErrs[1], err != nil, "Decimal: %v", err) && err == nil {
+				assert.Equal(t, tt.wantDec, d)
+			}
+
+			b, err := tt.f.Bool()
+			if assert.Equal(t, tt.wantErrs[2], err != nil, "Bool: %v", err) && err == nil {
+				assert.Equal(t, tt.wantBool, b)
+			}
+
+			tm, err := tt.f.Time()
+			if assert.Equal(t, tt.wantErrs[3], err != nil, "Time: %v", err) && err == nil {
+				assert.True(t, tt.wantTime.Equal(tm), "Time: got %v", tm)
+			}
+
+			c, err := tt.f.Code()
+			if assert.Equal(t, tt.wantErrs[4], err != nil, "Code: %v", err) && err == nil {
+				assert.Equal(t, tt.wantCode, c)
+			}
+		})
+	}
+}
+
+func Test_fieldParseError(t *testing.T) {
+	f := field{specID: "Retrieve spec 01", header: "Net Content", value: "twelve", address: "M4"}
+
+	_, err := f.Int()
+	assert.True(t, errors.Is(err, ErrValueParse))
+
+	var e *Error
+	if assert.True(t, errors.As(err, &e)) {
+		assert.Equal(t, "M4", e.Cell)
+		assert.Equal(t, "Retrieve spec 01", e.SpecID)
+	}
+}
+
+func TestGetFieldsForCellType(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, c)
+	assert.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, excelize.CellTypeString, got[0].CellType())
+		assert.Equal(t, "FREE_FROM -- Free from", got[0].RawValue())
+
+		code, err := got[0].Code()
+		assert.Nil(t, err)
+		assert.Equal(t, "FREE_FROM", code)
+		assert.Equal(t, "Free from", got[0].Description())
+	}
+}
+
+func TestGetFieldsForRawValue(t *testing.T) {
+	path := newTestFile(t, itemTestRows())
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, c)
+	require.Nil(t, err)
+	close(c)
+
+	got := collectFields(c)
+	require.Len(t, got, 1)
+	address := got[0].Address()
+
+	f, err := excelize.OpenFile(path)
+	require.Nil(t, err)
+	style, err := f.NewStyle(&excelize.Style{NumFmt: 2})
+	require.Nil(t, err)
+	require.Nil(t, f.SetCellValue(worksheetFSItem, address, 1234.5))
+	require.Nil(t, f.SetCellStyle(worksheetFSItem, address, address, style))
+	require.Nil(t, f.Save())
+	require.Nil(t, f.Close())
+
+	c = make(chan field, 10)
+	err = GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, c)
+	require.Nil(t, err)
+	close(c)
+
+	got = collectFields(c)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "1234.50", got[0].Value())
+		assert.Equal(t, "1234.5", got[0].RawValue())
+		assert.Equal(t, excelize.CellTypeNumber, got[0].CellType())
+	}
+}
+
+func Test_knownCellType(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		formatted string
+		want      excelize.CellType
+	}{
+		{name: "Empty", want: excelize.CellTypeUnset},
+		{name: "Boolean", raw: "1", formatted: "TRUE", want: excelize.CellTypeBool},
+		{name: "Formatted number", raw: "1234.5", formatted: "1234.50", want: excelize.CellTypeNumber},
+		{name: "Date", raw: "44713", formatted: "06-01-22", want: excelize.CellTypeNumber},
+		{name: "Numeric text", raw: "00011110603081", formatted: "00011110603081", want: excelize.CellTypeUnset},
+		{name: "Error", raw: "#N/A", formatted: "#N/A", want: excelize.CellTypeUnset},
+		{name: "Text", raw: "FREE_FROM -- Free from", formatted: "FREE_FROM -- Free from", want: excelize.CellTypeString},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, knownCellType(tt.raw, tt.formatted))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package fusereader
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// WarningKind describes the category of a Warning.
+type WarningKind int
+
+const (
+	WarningRaggedRow          WarningKind = iota // WarningRaggedRow indicates an item row ending before the key column of the field location.
+	WarningDuplicateItemID                       // WarningDuplicateItemID indicates an item ID appearing more than once within a file.
+	WarningRowBeforeFirstItem                    // WarningRowBeforeFirstItem indicates a non-blank row between the header row and the first item.
+	WarningEarlyTermination                      // WarningEarlyTermination indicates that reading stopped at the blank row limit, so any rows after it, whether blank or not, were not read.
+	WarningUnknownCode                           // WarningUnknownCode indicates a retrieved code-list value whose code is absent from its code list.
+	WarningInvalidGTIN                           // WarningInvalidGTIN indicates an item ID of type GTIN with an invalid format or check digit.
+)
+
+// String returns the name of the warning kind.
+func (k WarningKind) String() string {
+	switch k {
+	case WarningRaggedRow:
+		return "ragged row"
+	case WarningDuplicateItemID:
+		return "duplicate item ID"
+	case WarningRowBeforeFirstItem:
+		return "row before first item"
+	case WarningEarlyTermination:
+		return "early termination"
+	case WarningUnknownCode:
+		return "unknown code"
+	case WarningInvalidGTIN:
+		return "invalid GTIN"
+	}
+
+	return fmt.Sprintf("warning kind %d", int(k))
+}
+
+// Warning describes a non-fatal anomaly found while reading a file.
+type Warning struct {
+	Kind    WarningKind // Kind is the category of the anomaly.
+	File    string      // File is the path of the file containing the anomaly.
+	Row     int         // Row is the one-based number of the row containing the anomaly.
+	Cell    string      // Cell is the address of the cell of interest in A1 format, if any.
+	Message string      // Message describes the anomaly.
+}
+
+// String returns a description of the warning and its location.
+func (w Warning) String() string {
+	location := fmt.Sprintf("%s row %d", filepath.Base(w.File), w.Row)
+	if w.Cell != "" {
+		location = fmt.Sprintf("%s cell %s", filepath.Base(w.File), w.Cell)
+	}
+
+	return fmt.Sprintf("%s at %s: %s", w.Kind, location, w.Message)
+}
@@ -0,0 +1,35 @@
+package fusereader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFieldsReportWarnings(t *testing.T) {
+	rows := itemTestRows()
+	rows = append(rows[:1], append([][]string{{"", "", "Note"}}, rows[1:]...)...)
+	rows = append(rows, []string{itemRecordType, "ADD"})
+	rows = append(rows, rows[2])
+	rows = append(rows, []string{}, []string{}, []string{}, []string{itemRecordType})
+
+	path := newTestFile(t, rows)
+
+	var got []Warning
+
+	c := make(chan field, 10)
+	err := GetFields([]string{path}, []FieldLocation{validFieldLocation()}, []FieldRetrieval{validRetrieveSpec()}, c, ReportWarnings(func(w Warning) { got = append(got, w) }), SegmentItems(ItemBoundaries{BlankRowLimit: 2}))
+	require.Nil(t, err)
+	close(c)
+
+	want := []Warning{
+		{Kind: WarningRowBeforeFirstItem, File: path, Row: 2, Message: "row is not part of any item"},
+		{Kind: WarningRaggedRow, File: path, Row: 7, Cell: "G7", Message: "row has 2 cells, ending before the key column of spec Location spec 01"},
+		{Kind: WarningDuplicateItemID, File: path, Row: 8, Cell: "G8", Message: "item ID 00011110603081 was first seen on row 3"},
+		{Kind: WarningEarlyTermination, File: path, Row: 11, Message: "reading stopped after 3 consecutive blank rows"},
+	}
+	assert.Equal(t, want, got)
+
+	assert.Equal(t, "duplicate item ID at fuse.xlsx cell G8: item ID 00011110603081 was first seen on row 3", got[2].String())
+}